@@ -0,0 +1,58 @@
+// Command enumdiff reports the changes between two enum definition files, e.g. between the last release and HEAD.
+//
+// Usage:
+//
+//	enumdiff -old enums.v1.yaml -new enums.yaml -strict
+//
+// With -strict, enumdiff fails if a rename, merge, move or removal is not recorded in the new history.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/johannes-kuhfuss/services_utils/enums"
+)
+
+func main() {
+	oldPath := flag.String("old", "", "previous enum definition file (.json, .yaml or .yml)")
+	newPath := flag.String("new", "", "current enum definition file (.json, .yaml or .yml)")
+	asJSON := flag.Bool("json", false, "write the report as JSON")
+	strict := flag.Bool("strict", false, "fail if changes are missing from the history")
+	flag.Parse()
+
+	if err := run(*oldPath, *newPath, *asJSON, *strict, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "enumdiff: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(oldPath, newPath string, asJSON, strict bool, stdout io.Writer) error {
+	if oldPath == "" || newPath == "" {
+		return fmt.Errorf("-old and -new are required")
+	}
+	report, apiErr := enums.CompareFiles(oldPath, newPath)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := stdout.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	} else if _, err := io.WriteString(stdout, report.String()); err != nil {
+		return err
+	}
+
+	if undocumented := report.Undocumented(); strict && len(undocumented) > 0 {
+		return fmt.Errorf("%v change(s) missing from the history", len(undocumented))
+	}
+	return nil
+}
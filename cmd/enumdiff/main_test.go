@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johannes-kuhfuss/services_utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testOld     = filepath.Join("..", "..", "enums", "testdata", "enums.yaml")
+	testHistory = filepath.Join("..", "..", "enums", "testdata", "history.yaml")
+)
+
+func TestRunWritesReport(t *testing.T) {
+	var stdout bytes.Buffer
+
+	err := run(testOld, testHistory, false, true, &stdout)
+
+	assert.Nil(t, err)
+	assert.Contains(t, stdout.String(), "AccountTypes: renamed Advanced to Premium (index 1)\n")
+}
+
+func TestRunWritesJSON(t *testing.T) {
+	var stdout bytes.Buffer
+
+	err := run(testOld, testHistory, true, false, &stdout)
+
+	assert.Nil(t, err)
+	var report enums.Report
+	assert.Nil(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.EqualValues(t, 3, len(report.Differences))
+}
+
+func TestRunStrictFailsOnUndocumentedChanges(t *testing.T) {
+	var stdout bytes.Buffer
+	changed := filepath.Join(t.TempDir(), "enums.yaml")
+	data, _ := os.ReadFile(testOld)
+	_ = os.WriteFile(changed, bytes.Replace(data, []byte("Advanced"), []byte("Premium"), 1), 0o644)
+
+	assert.Nil(t, run(testOld, changed, false, false, &stdout))
+	err := run(testOld, changed, false, true, &stdout)
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, "1 change(s) missing from the history", err.Error())
+}
+
+func TestRunStrictAcceptsDocumentedMove(t *testing.T) {
+	var stdout bytes.Buffer
+	moved := filepath.Join(t.TempDir(), "enums.yaml")
+	data, _ := os.ReadFile(testOld)
+	data = bytes.Replace(data, []byte("      - index: 1\n        value: Advanced\n"), []byte("      - index: 5\n        value: Advanced\n"+
+		"    history:\n      - version: \"2\"\n        kind: moved\n        index: 1\n        value: Advanced\n        into: 5\n"), 1)
+	_ = os.WriteFile(moved, data, 0o644)
+
+	err := run(testOld, moved, false, true, &stdout)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "AccountTypes: moved Advanced from index 1 to 5\n", stdout.String())
+}
+
+func TestRunRejectsInvalidArguments(t *testing.T) {
+	assert.NotNil(t, run("", testHistory, false, false, nil))
+	assert.NotNil(t, run(testOld, "missing.yaml", false, false, nil))
+}
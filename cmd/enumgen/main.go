@@ -0,0 +1,58 @@
+// Command enumgen generates a type-safe Go enum from an enum definition file.
+//
+// Usage:
+//
+//	//go:generate go run github.com/johannes-kuhfuss/services_utils/cmd/enumgen -in enums.json -enum AccountTypes -type AccountType
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/enums"
+)
+
+func main() {
+	in := flag.String("in", "", "enum definition file (.json, .yaml or .yml)")
+	name := flag.String("enum", "", "name of the enum in the definition file")
+	typeName := flag.String("type", "", "name of the generated Go type")
+	varName := flag.String("var", "", "name of the generated enum variable (default: enum name)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file (default: $GOPACKAGE)")
+	out := flag.String("out", "", "output file (default: <type>_enum.go)")
+	flag.Parse()
+
+	if err := run(*in, *name, *out, enums.GenerateOptions{
+		Package:  *pkg,
+		TypeName: *typeName,
+		VarName:  *varName,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "enumgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, name, out string, opts enums.GenerateOptions) error {
+	if in == "" || name == "" || opts.TypeName == "" {
+		return fmt.Errorf("-in, -enum and -type are required")
+	}
+	defs, err := enums.ReadDefinitionsFile(in)
+	if err != nil {
+		return err
+	}
+	for _, def := range defs {
+		if def.Name != name {
+			continue
+		}
+		src, err := enums.Generate(def, opts)
+		if err != nil {
+			return err
+		}
+		if out == "" {
+			out = strings.ToLower(opts.TypeName) + "_enum.go"
+		}
+		return os.WriteFile(out, src, 0o644)
+	}
+	return fmt.Errorf("enum %v not found in %v", name, in)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johannes-kuhfuss/services_utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDefinitions = filepath.Join("..", "..", "enums", "testdata", "enums.json")
+
+func TestRunWritesGeneratedFile(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "accounttype_enum.go")
+
+	err := run(testDefinitions, "AccountTypes", out, enums.GenerateOptions{
+		Package:  "accounts",
+		TypeName: "AccountType",
+	})
+
+	assert.Nil(t, err)
+	data, readErr := os.ReadFile(out)
+	assert.Nil(t, readErr)
+	assert.Contains(t, string(data), "AccountTypeAdvanced AccountType = 1")
+}
+
+func TestRunMissingFlagsReturnsError(t *testing.T) {
+	err := run(testDefinitions, "AccountTypes", "", enums.GenerateOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestRunUnknownEnumReturnsError(t *testing.T) {
+	err := run(testDefinitions, "Unknown", "", enums.GenerateOptions{
+		Package:  "accounts",
+		TypeName: "AccountType",
+	})
+	assert.EqualError(t, err, "enum Unknown not found in "+testDefinitions)
+}
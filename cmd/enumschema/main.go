@@ -0,0 +1,89 @@
+// Command enumschema writes an OpenAPI components file with a schema for every enum in the given definition files.
+//
+// Usage:
+//
+//	enumschema -in enums.json -in more.yaml -format name -out components.yaml
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/enums"
+	"gopkg.in/yaml.v3"
+)
+
+type inputs []string
+
+func (i *inputs) String() string {
+	return strings.Join(*i, ",")
+}
+
+func (i *inputs) Set(value string) error {
+	*i = append(*i, value)
+	return nil
+}
+
+func main() {
+	var in inputs
+	flag.Var(&in, "in", "enum definition file (.json, .yaml or .yml), may be repeated")
+	format := flag.String("format", "name", "serialization of enum values: name or index")
+	out := flag.String("out", "", "output file, .json or .yaml/.yml (default: JSON on stdout)")
+	flag.Parse()
+
+	if err := run(in, *format, *out, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "enumschema: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in []string, format, out string, stdout io.Writer) error {
+	if len(in) == 0 {
+		return fmt.Errorf("at least one -in file is required")
+	}
+	var f enums.Format
+	switch format {
+	case "name":
+		f = enums.FormatName
+	case "index":
+		f = enums.FormatIndex
+	default:
+		return fmt.Errorf("unknown format %v", format)
+	}
+
+	registry := enums.NewRegistry()
+	for _, path := range in {
+		loaded, err := enums.LoadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := registry.RegisterAll(loaded); err != nil {
+			return err
+		}
+	}
+
+	data, err := encode(registry.Components(f), out)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(out, data, 0o644)
+}
+
+func encode(c enums.Components, out string) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(out)) {
+	case ".yaml", ".yml":
+		return yaml.Marshal(c)
+	default:
+		data, err := json.MarshalIndent(c, "", "  ")
+		return append(data, '\n'), err
+	}
+}
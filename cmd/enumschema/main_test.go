@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johannes-kuhfuss/services_utils/enums"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	testJSON     = filepath.Join("..", "..", "enums", "testdata", "enums.json")
+	testMetadata = filepath.Join("..", "..", "enums", "testdata", "metadata.yaml")
+)
+
+func TestRunWritesJSONToStdout(t *testing.T) {
+	var stdout bytes.Buffer
+
+	err := run([]string{testJSON, testMetadata}, "name", "", &stdout)
+
+	assert.Nil(t, err)
+	var c enums.Components
+	assert.Nil(t, json.Unmarshal(stdout.Bytes(), &c))
+	assert.EqualValues(t, 3, len(c.Components.Schemas))
+	assert.EqualValues(t, []any{"New", "Paid", "Shipped"}, c.Components.Schemas["OrderStatus"].Enum)
+}
+
+func TestRunWritesYAMLFile(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "components.yaml")
+
+	err := run([]string{testJSON}, "index", out, nil)
+
+	assert.Nil(t, err)
+	data, _ := os.ReadFile(out)
+	var c enums.Components
+	assert.Nil(t, yaml.Unmarshal(data, &c))
+	assert.EqualValues(t, "integer", c.Components.Schemas["AccountTypes"].Type)
+}
+
+func TestRunRejectsInvalidArguments(t *testing.T) {
+	assert.NotNil(t, run(nil, "name", "", nil))
+	assert.NotNil(t, run([]string{testJSON}, "bits", "", nil))
+	assert.NotNil(t, run([]string{testJSON, testJSON}, "name", "", nil))
+	assert.NotNil(t, run([]string{"missing.json"}, "name", "", nil))
+}
@@ -0,0 +1,175 @@
+package date
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Unit is a calendar bucket size. Unlike time.Truncate, buckets follow the wall clock of a location,
+// so a day bucket on a DST transition day is 23 or 25 hours long.
+type Unit int
+
+const (
+	UnitHour Unit = iota
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitQuarter
+)
+
+var unitNamesByUnit = map[Unit]string{
+	UnitHour:    "hour",
+	UnitDay:     "day",
+	UnitWeek:    "week",
+	UnitMonth:   "month",
+	UnitQuarter: "quarter",
+}
+
+// Bucket is the half-open interval [Start, End).
+type Bucket struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (b Bucket) Duration() time.Duration {
+	return b.End.Sub(b.Start)
+}
+
+func (b Bucket) Contains(t time.Time) bool {
+	return !t.Before(b.Start) && t.Before(b.End)
+}
+
+func (u Unit) String() string {
+	if name, ok := unitNamesByUnit[u]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unit(%d)", int(u))
+}
+
+// ParseUnit parses "hour", "day", "week", "month" or "quarter", ignoring case.
+func ParseUnit(s string) (Unit, api_error.ApiErr) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for unit, unitName := range unitNamesByUnit {
+		if unitName == name {
+			return unit, nil
+		}
+	}
+	return 0, api_error.NewBadRequestError(fmt.Sprintf("unknown bucket unit %v", s))
+}
+
+// TruncateTo returns the start of the bucket containing t in loc. Weeks start on Monday (ISO 8601).
+// A nil loc means UTC.
+func TruncateTo(t time.Time, unit Unit, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	y, m, d := t.Date()
+	switch unit {
+	case UnitHour:
+		// Subtract instead of using time.Date so that the repeated hour on a DST fall back day stays distinct.
+		return t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	case UnitDay:
+		return startOfDay(y, m, d, loc)
+	case UnitWeek:
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return startOfDay(y, m, d-daysSinceMonday, loc)
+	case UnitMonth:
+		return startOfDay(y, m, 1, loc)
+	case UnitQuarter:
+		return startOfDay(y, m-(m-1)%3, 1, loc)
+	default:
+		return t
+	}
+}
+
+// startOfDay returns the first instant of the given day in loc. Where DST starts at midnight, e.g. in
+// America/Sao_Paulo before 2019, midnight does not exist and time.Date returns 23:00 of the previous day; the day
+// then starts at the end of the DST gap.
+func startOfDay(y int, m time.Month, d int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	wantY, wantM, wantD := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Date()
+	if gotY, gotM, gotD := t.Date(); gotY != wantY || gotM != wantM || gotD != wantD {
+		_, end := t.ZoneBounds()
+		return end
+	}
+	return t
+}
+
+// NextBucketStart returns the start of the bucket following the one containing t.
+func NextBucketStart(t time.Time, unit Unit, loc *time.Location) time.Time {
+	start := TruncateTo(t, unit, loc)
+	y, m, d := start.Date()
+	switch unit {
+	case UnitHour:
+		return start.Add(time.Hour)
+	case UnitDay:
+		return startOfDay(y, m, d+1, start.Location())
+	case UnitWeek:
+		return startOfDay(y, m, d+7, start.Location())
+	case UnitMonth:
+		return startOfDay(y, m+1, 1, start.Location())
+	case UnitQuarter:
+		return startOfDay(y, m+3, 1, start.Location())
+	default:
+		return start
+	}
+}
+
+// BucketOf returns the bucket containing t.
+func BucketOf(t time.Time, unit Unit, loc *time.Location) Bucket {
+	return Bucket{
+		Start: TruncateTo(t, unit, loc),
+		End:   NextBucketStart(t, unit, loc),
+	}
+}
+
+// Buckets enumerates the buckets overlapping [from, to) in loc. The first bucket starts at or before from.
+func Buckets(from, to time.Time, unit Unit, loc *time.Location) []Bucket {
+	buckets := make([]Bucket, 0)
+	if !from.Before(to) {
+		return buckets
+	}
+	if _, ok := unitNamesByUnit[unit]; !ok {
+		return buckets
+	}
+	for start := TruncateTo(from, unit, loc); start.Before(to); {
+		end := NextBucketStart(start, unit, loc)
+		if !end.After(start) {
+			break
+		}
+		buckets = append(buckets, Bucket{Start: start, End: end})
+		start = end
+	}
+	return buckets
+}
+
+// ISOWeek returns the ISO 8601 year and week number of t in loc. A nil loc means UTC.
+func ISOWeek(t time.Time, loc *time.Location) (year, week int) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).ISOWeek()
+}
+
+// ISOWeekStart returns midnight of the Monday starting the given ISO week in loc.
+func ISOWeekStart(year, week int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	// January 4th is always in week 1.
+	jan4 := startOfDay(year, time.January, 4, loc)
+	monday := TruncateTo(jan4, UnitWeek, loc)
+	y, m, d := monday.Date()
+	return startOfDay(y, m, d+(week-1)*7, loc)
+}
+
+// ISOWeeksInYear returns the number of ISO weeks (52 or 53) in the given ISO year.
+func ISOWeeksInYear(year int) int {
+	// December 28th is always in the last week of its ISO year.
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
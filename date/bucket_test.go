@@ -0,0 +1,175 @@
+package date
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestTruncateToInLocation(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	// 2026-05-13 is a Wednesday, 23:30 UTC is already Thursday in Berlin.
+	ts := time.Date(2026, 5, 13, 23, 30, 15, 0, time.UTC)
+	tests := []struct {
+		unit Unit
+		want time.Time
+	}{
+		{unit: UnitHour, want: time.Date(2026, 5, 14, 1, 0, 0, 0, berlin)},
+		{unit: UnitDay, want: time.Date(2026, 5, 14, 0, 0, 0, 0, berlin)},
+		{unit: UnitWeek, want: time.Date(2026, 5, 11, 0, 0, 0, 0, berlin)},
+		{unit: UnitMonth, want: time.Date(2026, 5, 1, 0, 0, 0, 0, berlin)},
+		{unit: UnitQuarter, want: time.Date(2026, 4, 1, 0, 0, 0, 0, berlin)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit.String(), func(t *testing.T) {
+			result := TruncateTo(ts, tt.unit, berlin)
+			assert.True(t, tt.want.Equal(result), result.String())
+			assert.EqualValues(t, berlin, result.Location())
+		})
+	}
+}
+
+func TestTruncateToNilLocationUsesUtc(t *testing.T) {
+	ts := time.Date(2026, 5, 13, 23, 30, 0, 0, time.UTC)
+	result := TruncateTo(ts, UnitDay, nil)
+	assert.EqualValues(t, time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC), result)
+}
+
+func TestTruncateToHourWithHalfHourOffset(t *testing.T) {
+	kolkata := mustLoad(t, "Asia/Kolkata")
+	ts := time.Date(2026, 5, 13, 10, 10, 0, 0, time.UTC) // 15:40 in Kolkata
+
+	result := TruncateTo(ts, UnitHour, kolkata)
+
+	assert.EqualValues(t, time.Date(2026, 5, 13, 9, 30, 0, 0, time.UTC), result.UTC())
+}
+
+func TestBucketsDstDays(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	tests := []struct {
+		name  string
+		day   time.Time
+		hours int
+	}{
+		{name: "spring forward", day: time.Date(2026, 3, 29, 12, 0, 0, 0, berlin), hours: 23},
+		{name: "fall back", day: time.Date(2026, 10, 25, 12, 0, 0, 0, berlin), hours: 25},
+		{name: "regular", day: time.Date(2026, 10, 26, 12, 0, 0, 0, berlin), hours: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := BucketOf(tt.day, UnitDay, berlin)
+			assert.EqualValues(t, time.Duration(tt.hours)*time.Hour, b.Duration())
+
+			hours := Buckets(b.Start, b.End, UnitHour, berlin)
+			assert.EqualValues(t, tt.hours, len(hours))
+			for i := 1; i < len(hours); i++ {
+				assert.True(t, hours[i].Start.Equal(hours[i-1].End))
+				assert.EqualValues(t, time.Hour, hours[i].Duration())
+			}
+		})
+	}
+}
+
+func TestBucketsDstStartingAtMidnight(t *testing.T) {
+	saoPaulo := mustLoad(t, "America/Sao_Paulo")
+	// On 2018-11-04 clocks jumped from 00:00 to 01:00, so the day starts at 01:00.
+	from := time.Date(2018, 11, 3, 12, 0, 0, 0, saoPaulo)
+	to := time.Date(2018, 11, 6, 0, 0, 0, 0, saoPaulo)
+
+	days := Buckets(from, to, UnitDay, saoPaulo)
+
+	assert.EqualValues(t, 3, len(days))
+	gapDay := days[1]
+	assert.EqualValues(t, "2018-11-04T01:00:00-02:00", gapDay.Start.Format(time.RFC3339))
+	assert.EqualValues(t, 23*time.Hour, gapDay.Duration())
+	assert.True(t, days[0].End.Equal(gapDay.Start))
+	assert.True(t, TruncateTo(time.Date(2018, 11, 4, 12, 0, 0, 0, saoPaulo), UnitDay, saoPaulo).Equal(gapDay.Start))
+	assert.EqualValues(t, "2018-11-01T00:00:00-03:00", TruncateTo(gapDay.Start, UnitMonth, saoPaulo).Format(time.RFC3339))
+}
+
+func TestBucketsEnumeratesMonthsAndQuarters(t *testing.T) {
+	from := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	months := Buckets(from, to, UnitMonth, time.UTC)
+	quarters := Buckets(from, to, UnitQuarter, time.UTC)
+
+	assert.EqualValues(t, 6, len(months))
+	assert.EqualValues(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), months[0].Start)
+	assert.EqualValues(t, time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), months[5].End)
+	assert.True(t, months[0].Contains(from))
+	assert.EqualValues(t, 3, len(quarters))
+	assert.EqualValues(t, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), quarters[2].Start)
+}
+
+func TestBucketsEmptyRangeReturnsNoBuckets(t *testing.T) {
+	now := time.Now()
+	assert.EqualValues(t, 0, len(Buckets(now, now, UnitDay, nil)))
+	assert.EqualValues(t, 0, len(Buckets(now, now.Add(time.Hour), Unit(42), nil)))
+}
+
+func TestBucketsWeeksStartOnMonday(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) // Thursday
+	to := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	weeks := Buckets(from, to, UnitWeek, nil)
+
+	assert.EqualValues(t, 3, len(weeks))
+	for _, w := range weeks {
+		assert.EqualValues(t, time.Monday, w.Start.Weekday())
+		assert.EqualValues(t, 7*24*time.Hour, w.Duration())
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	unit, err := ParseUnit(" Quarter ")
+	assert.Nil(t, err)
+	assert.EqualValues(t, UnitQuarter, unit)
+
+	_, err = ParseUnit("fortnight")
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.StatusCode())
+	assert.EqualValues(t, "Unit(42)", Unit(42).String())
+}
+
+func TestISOWeek(t *testing.T) {
+	// 2027-01-01 is a Friday and belongs to week 53 of 2026.
+	year, week := ISOWeek(time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC), nil)
+	assert.EqualValues(t, 2026, year)
+	assert.EqualValues(t, 53, week)
+
+	// Sunday evening in UTC is already Monday in Tokyo.
+	tokyo := mustLoad(t, "Asia/Tokyo")
+	year, week = ISOWeek(time.Date(2026, 5, 17, 20, 0, 0, 0, time.UTC), tokyo)
+	assert.EqualValues(t, 2026, year)
+	assert.EqualValues(t, 21, week)
+}
+
+func TestISOWeekStart(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	assert.EqualValues(t, time.Date(2025, 12, 29, 0, 0, 0, 0, berlin), ISOWeekStart(2026, 1, berlin))
+	assert.EqualValues(t, time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC), ISOWeekStart(2026, 53, nil))
+
+	year, week := ISOWeek(ISOWeekStart(2026, 20, berlin), berlin)
+	assert.EqualValues(t, 2026, year)
+	assert.EqualValues(t, 20, week)
+}
+
+func TestISOWeeksInYear(t *testing.T) {
+	assert.EqualValues(t, 53, ISOWeeksInYear(2026))
+	assert.EqualValues(t, 52, ISOWeeksInYear(2027))
+	assert.EqualValues(t, 53, ISOWeeksInYear(2020))
+}
@@ -21,9 +21,9 @@ func GetNowUtc() time.Time {
 }
 
 func GetNowLocal(location string) (*time.Time, api_error.ApiErr) {
-	loc, err := time.LoadLocation(location)
+	loc, err := LoadLocation(location)
 	if err != nil {
-		return nil, api_error.NewBadRequestError("could not parse location")
+		return nil, err
 	}
 	localtime := time.Now().In(loc)
 	return &localtime, nil
@@ -0,0 +1,205 @@
+package date
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+const (
+	maxLocationSuggestions = 3
+	maxSuggestionDistance  = 3
+)
+
+var (
+	locationCacheMu sync.RWMutex
+	locationCache   = make(map[string]*time.Location)
+
+	zoneNamesByLower = func() map[string]string {
+		m := make(map[string]string, len(zoneNames)+len(legacyZoneNames))
+		for _, name := range slices.Concat(zoneNames, legacyZoneNames) {
+			m[strings.ToLower(name)] = name
+		}
+		return m
+	}()
+	maxZoneNameLength = func() int {
+		n := 0
+		for _, name := range zoneNames {
+			n = max(n, len(name))
+		}
+		return n
+	}()
+
+	// locationAliases maps lower case alternative spellings to IANA names.
+	locationAliases = map[string]string{
+		"utc":       "UTC",
+		"gmt":       "UTC",
+		"z":         "UTC",
+		"zulu":      "UTC",
+		"universal": "UTC",
+		"eastern":   "America/New_York",
+		"central":   "America/Chicago",
+		"mountain":  "America/Denver",
+		"pacific":   "America/Los_Angeles",
+		"alaska":    "America/Anchorage",
+		"hawaii":    "Pacific/Honolulu",
+	}
+
+	// offsetPattern matches fixed offsets such as "+02:00", "-0530", "UTC+2" or "GMT-03:30".
+	offsetPattern = regexp.MustCompile(`^(?i:utc|gmt)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+)
+
+// Locations returns the IANA time zone names that can be offered for selection, e.g. in a drop-down.
+func Locations() []string {
+	return append([]string(nil), zoneNames...)
+}
+
+// IsValidLocation reports whether LoadLocation is able to resolve the given location.
+func IsValidLocation(location string) bool {
+	_, err := LoadLocation(location)
+	return err == nil
+}
+
+// LoadLocation resolves IANA names (case-insensitive), fixed offsets and common aliases to a location.
+// Resolved zones are cached by their IANA name. An empty location resolves to UTC.
+func LoadLocation(location string) (*time.Location, api_error.ApiErr) {
+	name := canonicalLocation(strings.TrimSpace(location))
+	if name == "" {
+		return time.UTC, nil
+	}
+	if loc, ok := parseOffset(name); ok {
+		return loc, nil
+	}
+
+	locationCacheMu.RLock()
+	loc, ok := locationCache[name]
+	locationCacheMu.RUnlock()
+	if ok {
+		return loc, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, locationError(name)
+	}
+
+	locationCacheMu.Lock()
+	locationCache[name] = loc
+	locationCacheMu.Unlock()
+	return loc, nil
+}
+
+// canonicalLocation returns the IANA name for aliases and known zones in any case, otherwise name.
+func canonicalLocation(name string) string {
+	lower := strings.ToLower(name)
+	if alias, ok := locationAliases[lower]; ok {
+		return alias
+	}
+	if canonical, ok := zoneNamesByLower[lower]; ok {
+		return canonical
+	}
+	return name
+}
+
+func locationError(name string) api_error.ApiErr {
+	suggestions := suggestLocations(name)
+	msg := fmt.Sprintf("could not parse location %v", name)
+	if len(suggestions) == 0 {
+		return api_error.NewBadRequestError(msg)
+	}
+	causes := make([]any, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		causes = append(causes, suggestion)
+	}
+	msg = fmt.Sprintf("%v, did you mean %v?", msg, strings.Join(suggestions, ", "))
+	return api_error.NewError(msg, http.StatusBadRequest, causes)
+}
+
+func parseOffset(name string) (*time.Location, bool) {
+	match := offsetPattern.FindStringSubmatch(strings.ReplaceAll(name, " ", ""))
+	if match == nil {
+		return nil, false
+	}
+	hours, _ := strconv.Atoi(match[2])
+	minutes := 0
+	if match[3] != "" {
+		minutes, _ = strconv.Atoi(match[3])
+	}
+	if hours > 14 || minutes > 59 {
+		return nil, false
+	}
+	offset := hours*3600 + minutes*60
+	if offset == 0 {
+		return time.UTC, true
+	}
+	if match[1] == "-" {
+		offset = -offset
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%v%02d:%02d", match[1], hours, minutes), offset), true
+}
+
+// suggestLocations returns the zone names closest to the given name, comparing both the full
+// name and the part after the last slash, so that "berlin" suggests "Europe/Berlin". Names longer than any
+// zone name are not compared, as no zone would be close enough.
+func suggestLocations(name string) []string {
+	if utf8.RuneCountInString(name) > maxZoneNameLength+maxSuggestionDistance {
+		return nil
+	}
+	type candidate struct {
+		name     string
+		distance int
+	}
+	lower := strings.ToLower(name)
+	candidates := make([]candidate, 0)
+	for _, zone := range zoneNames {
+		zoneLower := strings.ToLower(zone)
+		distance := levenshtein(lower, zoneLower)
+		if i := strings.LastIndex(zoneLower, "/"); i >= 0 {
+			distance = min(distance, levenshtein(lower, zoneLower[i+1:]))
+		}
+		if distance <= maxSuggestionDistance {
+			candidates = append(candidates, candidate{name: zone, distance: distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	suggestions := make([]string, 0, maxLocationSuggestions)
+	for i := 0; i < len(candidates) && i < maxLocationSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,188 @@
+package date
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadLocationResolvesNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		want     string
+	}{
+		{
+			name:     "iana",
+			location: "Europe/Berlin",
+			want:     "Europe/Berlin",
+		},
+		{
+			name:     "iana ignores case",
+			location: "europe/berlin",
+			want:     "Europe/Berlin",
+		},
+		{
+			name:     "empty",
+			location: "",
+			want:     "UTC",
+		},
+		{
+			name:     "alias",
+			location: "Zulu",
+			want:     "UTC",
+		},
+		{
+			name:     "alias to region",
+			location: "Pacific",
+			want:     "America/Los_Angeles",
+		},
+		{
+			name:     "trims whitespace",
+			location: " UTC ",
+			want:     "UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadLocation(tt.location)
+			assert.Nil(t, err)
+			assert.EqualValues(t, tt.want, loc.String())
+		})
+	}
+}
+
+func TestLoadLocationResolvesOffsets(t *testing.T) {
+	tests := []struct {
+		location string
+		offset   int
+	}{
+		{location: "+02:00", offset: 2 * 3600},
+		{location: "-0530", offset: -(5*3600 + 30*60)},
+		{location: "UTC+2", offset: 2 * 3600},
+		{location: "GMT-03:30", offset: -(3*3600 + 30*60)},
+		{location: "UTC+0", offset: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			loc, err := LoadLocation(tt.location)
+			assert.Nil(t, err)
+			_, offset := time.Date(2026, 1, 1, 0, 0, 0, 0, loc).Zone()
+			assert.EqualValues(t, tt.offset, offset)
+		})
+	}
+}
+
+func TestLoadLocationRejectsInvalidOffset(t *testing.T) {
+	loc, err := LoadLocation("+15:00")
+	assert.Nil(t, loc)
+	assert.NotNil(t, err)
+}
+
+func TestLoadLocationCachesLocation(t *testing.T) {
+	first, err1 := LoadLocation("America/New_York")
+	second, err2 := LoadLocation("America/New_York")
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Same(t, first, second)
+}
+
+func TestLoadLocationCachesByCanonicalName(t *testing.T) {
+	first, err1 := LoadLocation("EUROPE/paris")
+	second, err2 := LoadLocation("europe/PARIS")
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Same(t, first, second)
+
+	locationCacheMu.RLock()
+	defer locationCacheMu.RUnlock()
+	assert.Contains(t, locationCache, "Europe/Paris")
+	assert.NotContains(t, locationCache, "EUROPE/paris")
+	assert.NotContains(t, locationCache, "europe/PARIS")
+}
+
+func TestLoadLocationDoesNotCacheFailures(t *testing.T) {
+	_, err := LoadLocation("Europe/Nowhere")
+	assert.NotNil(t, err)
+
+	locationCacheMu.RLock()
+	defer locationCacheMu.RUnlock()
+	assert.NotContains(t, locationCache, "Europe/Nowhere")
+}
+
+func TestLoadLocationLongInputReturnsNoSuggestions(t *testing.T) {
+	_, err := LoadLocation(strings.Repeat("Europe/Berlin", 1000))
+	assert.NotNil(t, err)
+	assert.Nil(t, err.Causes())
+}
+
+func TestLoadLocationMisspelledReturnsSuggestions(t *testing.T) {
+	loc, err := LoadLocation("Europe/Berln")
+	assert.Nil(t, loc)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.StatusCode())
+	assert.Contains(t, err.Message(), "did you mean")
+	assert.Contains(t, err.Causes(), "Europe/Berlin")
+}
+
+func TestLoadLocationCityReturnsSuggestion(t *testing.T) {
+	_, err := LoadLocation("Berlin")
+	assert.NotNil(t, err)
+	assert.EqualValues(t, "Europe/Berlin", err.Causes()[0])
+}
+
+func TestLoadLocationUnknownReturnsNoSuggestions(t *testing.T) {
+	_, err := LoadLocation("wrong location")
+	assert.NotNil(t, err)
+	assert.EqualValues(t, "could not parse location wrong location", err.Message())
+	assert.Nil(t, err.Causes())
+}
+
+func TestLocationsReturnsCopy(t *testing.T) {
+	l := Locations()
+	assert.Contains(t, l, "Europe/Berlin")
+	assert.Contains(t, l, "UTC")
+	l[0] = "changed"
+	assert.NotContains(t, Locations(), "changed")
+}
+
+func TestLocationsAreValid(t *testing.T) {
+	for _, name := range Locations() {
+		assert.True(t, IsValidLocation(name), name)
+	}
+}
+
+func TestLocationsOfferOnlyCanonicalZones(t *testing.T) {
+	l := Locations()
+
+	assert.Contains(t, l, "Asia/Kolkata")
+	assert.Contains(t, l, "Europe/Kyiv")
+	for _, legacy := range []string{"Asia/Calcutta", "Asia/Saigon", "Europe/Kiev", "Africa/Timbuktu", "Pacific/Truk", "Pacific/Samoa"} {
+		assert.NotContains(t, l, legacy)
+	}
+}
+
+func TestLoadLocationAcceptsLegacyNames(t *testing.T) {
+	for _, name := range legacyZoneNames {
+		loc, err := LoadLocation(strings.ToLower(name))
+		assert.Nil(t, err, name)
+		assert.EqualValues(t, name, loc.String())
+	}
+}
+
+func TestIsValidLocation(t *testing.T) {
+	assert.True(t, IsValidLocation("Asia/Tokyo"))
+	assert.False(t, IsValidLocation("Asia/Tokio"))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.EqualValues(t, 0, levenshtein("berlin", "berlin"))
+	assert.EqualValues(t, 1, levenshtein("berln", "berlin"))
+	assert.EqualValues(t, 3, levenshtein("kitten", "sitting"))
+	assert.EqualValues(t, 3, levenshtein("", "abc"))
+}
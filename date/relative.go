@@ -0,0 +1,190 @@
+package date
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Language selects the wording used by Humanizer.
+type Language string
+
+const (
+	English Language = "en"
+	German  Language = "de"
+
+	// DefaultRelativeThreshold is the distance after which relative times are shown as absolute ApiDateLayout.
+	DefaultRelativeThreshold = 30 * 24 * time.Hour
+
+	justNowLimit = 45 * time.Second
+	day          = 24 * time.Hour
+	week         = 7 * day
+	month        = 30 * day
+	year         = 365 * day
+)
+
+type unitNames struct {
+	singular string
+	plural   string
+}
+
+type phrases struct {
+	justNow string
+	past    string
+	future  string
+	// units are minute, hour, day, week, month, year
+	units [6]unitNames
+	// durationUnits are day, hour, minute, second, millisecond
+	durationUnits [5]string
+}
+
+var languages = map[Language]phrases{
+	English: {
+		justNow: "just now",
+		past:    "%d %s ago",
+		future:  "in %d %s",
+		units: [6]unitNames{
+			{"minute", "minutes"},
+			{"hour", "hours"},
+			{"day", "days"},
+			{"week", "weeks"},
+			{"month", "months"},
+			{"year", "years"},
+		},
+		durationUnits: [5]string{"%dd", "%dh", "%dm", "%ds", "%dms"},
+	},
+	German: {
+		justNow: "gerade eben",
+		past:    "vor %d %s",
+		future:  "in %d %s",
+		units: [6]unitNames{
+			{"Minute", "Minuten"},
+			{"Stunde", "Stunden"},
+			{"Tag", "Tagen"},
+			{"Woche", "Wochen"},
+			{"Monat", "Monaten"},
+			{"Jahr", "Jahren"},
+		},
+		durationUnits: [5]string{"%d Tg.", "%d Std.", "%d Min.", "%d Sek.", "%d ms"},
+	},
+}
+
+// Humanizer formats times and durations for humans. The zero value formats in English against
+// time.Now and never falls back to absolute times.
+type Humanizer struct {
+	Language Language
+	// Threshold is the distance from now after which RelativeTime returns the ApiDateLayout; 0 disables the fallback.
+	Threshold time.Duration
+	// Now is the clock to compare against; nil uses time.Now.
+	Now func() time.Time
+}
+
+// ParseLanguage maps a language tag such as "de-DE" to a supported language, defaulting to English.
+func ParseLanguage(tag string) Language {
+	lang := Language(strings.ToLower(strings.TrimSpace(tag)))
+	if i := strings.IndexAny(string(lang), "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := languages[lang]; ok {
+		return lang
+	}
+	return English
+}
+
+// FormatRelative formats t relative to now, e.g. "3 minutes ago" or "in 2 days",
+// falling back to ApiDateLayout beyond DefaultRelativeThreshold.
+func FormatRelative(t time.Time, lang Language) string {
+	return Humanizer{Language: lang, Threshold: DefaultRelativeThreshold}.RelativeTime(t)
+}
+
+// FormatDuration formats d compactly using its two most significant units, e.g. "1h 5m".
+func FormatDuration(d time.Duration, lang Language) string {
+	return Humanizer{Language: lang}.Duration(d)
+}
+
+func (h Humanizer) phrases() phrases {
+	if p, ok := languages[h.Language]; ok {
+		return p
+	}
+	return languages[English]
+}
+
+func (h Humanizer) now() time.Time {
+	if h.Now == nil {
+		return time.Now()
+	}
+	return h.Now()
+}
+
+// RelativeTime formats t relative to the clock, e.g. "3 minutes ago" or "in 2 days".
+func (h Humanizer) RelativeTime(t time.Time) string {
+	p := h.phrases()
+	diff := t.Sub(h.now())
+	future := diff > 0
+	if diff < 0 {
+		diff = -diff
+	}
+	if h.Threshold > 0 && diff >= h.Threshold {
+		return t.Format(ApiDateLayout)
+	}
+	if diff < justNowLimit {
+		return p.justNow
+	}
+
+	var count int64
+	var unit unitNames
+	switch {
+	case diff < time.Hour:
+		count, unit = max(int64(diff/time.Minute), 1), p.units[0]
+	case diff < day:
+		count, unit = int64(diff/time.Hour), p.units[1]
+	case diff < week:
+		count, unit = int64(diff/day), p.units[2]
+	case diff < month:
+		count, unit = int64(diff/week), p.units[3]
+	case diff < year:
+		count, unit = int64(diff/month), p.units[4]
+	default:
+		count, unit = int64(diff/year), p.units[5]
+	}
+
+	name := unit.plural
+	if count == 1 {
+		name = unit.singular
+	}
+	if future {
+		return fmt.Sprintf(p.future, count, name)
+	}
+	return fmt.Sprintf(p.past, count, name)
+}
+
+// Duration formats d compactly using its two most significant units, e.g. "1h 5m".
+func (h Humanizer) Duration(d time.Duration) string {
+	p := h.phrases()
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	if d < time.Second {
+		return sign + fmt.Sprintf(p.durationUnits[4], d.Milliseconds())
+	}
+
+	sizes := [4]time.Duration{day, time.Hour, time.Minute, time.Second}
+	parts := make([]string, 0, 2)
+	for i, size := range sizes {
+		count := int64(d / size)
+		d %= size
+		if count == 0 {
+			if len(parts) > 0 {
+				break
+			}
+			continue
+		}
+		parts = append(parts, fmt.Sprintf(p.durationUnits[i], count))
+		if len(parts) == 2 {
+			break
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
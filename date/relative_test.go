@@ -0,0 +1,114 @@
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var fixedNow = time.Date(2026, 5, 18, 8, 0, 0, 0, time.UTC)
+
+func fixedClock() time.Time {
+	return fixedNow
+}
+
+func TestRelativeTimeEnglish(t *testing.T) {
+	h := Humanizer{Language: English, Now: fixedClock}
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{offset: -10 * time.Second, want: "just now"},
+		{offset: 30 * time.Second, want: "just now"},
+		{offset: -50 * time.Second, want: "1 minute ago"},
+		{offset: -3 * time.Minute, want: "3 minutes ago"},
+		{offset: 1 * time.Hour, want: "in 1 hour"},
+		{offset: -5 * time.Hour, want: "5 hours ago"},
+		{offset: 2 * 24 * time.Hour, want: "in 2 days"},
+		{offset: -15 * 24 * time.Hour, want: "2 weeks ago"},
+		{offset: -65 * 24 * time.Hour, want: "2 months ago"},
+		{offset: 800 * 24 * time.Hour, want: "in 2 years"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.EqualValues(t, tt.want, h.RelativeTime(fixedNow.Add(tt.offset)))
+		})
+	}
+}
+
+func TestRelativeTimeGerman(t *testing.T) {
+	h := Humanizer{Language: German, Now: fixedClock}
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{offset: -10 * time.Second, want: "gerade eben"},
+		{offset: -1 * time.Minute, want: "vor 1 Minute"},
+		{offset: -3 * time.Minute, want: "vor 3 Minuten"},
+		{offset: 1 * time.Hour, want: "in 1 Stunde"},
+		{offset: 2 * 24 * time.Hour, want: "in 2 Tagen"},
+		{offset: -24 * time.Hour, want: "vor 1 Tag"},
+		{offset: -400 * 24 * time.Hour, want: "vor 1 Jahr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.EqualValues(t, tt.want, h.RelativeTime(fixedNow.Add(tt.offset)))
+		})
+	}
+}
+
+func TestRelativeTimeBeyondThresholdReturnsAbsolute(t *testing.T) {
+	h := Humanizer{Language: English, Threshold: 24 * time.Hour, Now: fixedClock}
+	then := fixedNow.Add(-48 * time.Hour)
+
+	result := h.RelativeTime(then)
+
+	assert.EqualValues(t, "2026-05-16T08:00:00Z", result)
+	assert.True(t, IsValidTime(result))
+}
+
+func TestRelativeTimeUnknownLanguageUsesEnglish(t *testing.T) {
+	h := Humanizer{Language: "fr", Now: fixedClock}
+	assert.EqualValues(t, "3 minutes ago", h.RelativeTime(fixedNow.Add(-3*time.Minute)))
+}
+
+func TestFormatRelativeUsesClock(t *testing.T) {
+	assert.EqualValues(t, "5 minutes ago", FormatRelative(time.Now().Add(-5*time.Minute-time.Second), English))
+	old := time.Now().Add(-2 * DefaultRelativeThreshold)
+	assert.EqualValues(t, old.Format(ApiDateLayout), FormatRelative(old, German))
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		lang Language
+		want string
+	}{
+		{d: time.Hour + 5*time.Minute + 30*time.Second, lang: English, want: "1h 5m"},
+		{d: 26 * time.Hour, lang: English, want: "1d 2h"},
+		{d: 24*time.Hour + 5*time.Minute, lang: English, want: "1d"},
+		{d: 45 * time.Second, lang: English, want: "45s"},
+		{d: 350 * time.Millisecond, lang: English, want: "350ms"},
+		{d: 0, lang: English, want: "0ms"},
+		{d: -90 * time.Second, lang: English, want: "-1m 30s"},
+		{d: time.Hour + 5*time.Minute, lang: German, want: "1 Std. 5 Min."},
+		{d: 50 * time.Hour, lang: German, want: "2 Tg. 2 Std."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.EqualValues(t, tt.want, FormatDuration(tt.d, tt.lang))
+		})
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	assert.EqualValues(t, German, ParseLanguage("de-DE"))
+	assert.EqualValues(t, German, ParseLanguage(" DE "))
+	assert.EqualValues(t, English, ParseLanguage("en_US"))
+	assert.EqualValues(t, English, ParseLanguage("fr"))
+	assert.EqualValues(t, English, ParseLanguage(""))
+}
@@ -0,0 +1,233 @@
+package enums
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+	"gopkg.in/yaml.v3"
+)
+
+// Usage (JSON, YAML uses the same keys):
+//
+//	{"enums": [{"name": "AccountTypes", "items": [{"index": 0, "value": "Basic"}, {"index": 1, "value": "Advanced"}]}]}
+//
+// Items may also set label, labels, description, deprecated, replacedBy, aliases and attributes.
+// An enum may list its changes under "history", e.g. {"version": "2", "kind": "renamed", "index": 1, "value": "Advanced"}.
+
+type ItemDefinition struct {
+	Index       int32             `json:"index" yaml:"index"`
+	Value       string            `json:"value" yaml:"value"`
+	Label       string            `json:"label,omitempty" yaml:"label,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Deprecated  bool              `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	ReplacedBy  *int32            `json:"replacedBy,omitempty" yaml:"replacedBy,omitempty"`
+	Aliases     []string          `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Attributes  map[string]any    `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+type Definition struct {
+	Name    string           `json:"name" yaml:"name"`
+	Items   []ItemDefinition `json:"items" yaml:"items"`
+	History []Change         `json:"history,omitempty" yaml:"history,omitempty"`
+}
+
+type Definitions []Definition
+
+type definitionFile struct {
+	Enums Definitions `json:"enums" yaml:"enums"`
+}
+
+func DefinitionsFromJSON(data []byte) (Definitions, api_error.ApiErr) {
+	var file definitionFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, parseError(err)
+	}
+	return file.Enums, nil
+}
+
+func DefinitionsFromYAML(data []byte) (Definitions, api_error.ApiErr) {
+	var file definitionFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && err != io.EOF {
+		return nil, parseError(err)
+	}
+	return file.Enums, nil
+}
+
+// parseError reports a malformed definition file as bad input, like an unsupported file type.
+func parseError(err error) api_error.ApiErr {
+	return api_error.NewError("could not parse enum definitions", http.StatusBadRequest, []any{err.Error()})
+}
+
+// ReadDefinitions reads a .json, .yaml or .yml definition file from fsys, e.g. an embed.FS.
+func ReadDefinitions(fsys fs.FS, path string) (Definitions, api_error.ApiErr) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, api_error.NewInternalServerError(fmt.Sprintf("could not read enum definitions from %v", path), err)
+	}
+	return parseDefinitions(path, data)
+}
+
+// ReadDefinitionsFile reads a .json, .yaml or .yml definition file from disk.
+func ReadDefinitionsFile(path string) (Definitions, api_error.ApiErr) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, api_error.NewInternalServerError(fmt.Sprintf("could not read enum definitions from %v", path), err)
+	}
+	return parseDefinitions(path, data)
+}
+
+func parseDefinitions(path string, data []byte) (Definitions, api_error.ApiErr) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return DefinitionsFromJSON(data)
+	case ".yaml", ".yml":
+		return DefinitionsFromYAML(data)
+	default:
+		return nil, api_error.NewBadRequestError(fmt.Sprintf("unsupported enum definition file type %v", path))
+	}
+}
+
+// LoadFS reads and validates a definition file from fsys and returns its enums by name.
+func LoadFS(fsys fs.FS, path string) (map[string]*Enum, api_error.ApiErr) {
+	defs, err := ReadDefinitions(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	return defs.Enums()
+}
+
+// LoadFile reads and validates a definition file from disk and returns its enums by name.
+func LoadFile(path string) (map[string]*Enum, api_error.ApiErr) {
+	defs, err := ReadDefinitionsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return defs.Enums()
+}
+
+func (d Definition) Enum() (*Enum, api_error.ApiErr) {
+	e := &Enum{
+		Items: make([]EnumItem, 0, len(d.Items)),
+	}
+	e.SetHistory(d.History)
+	for _, item := range d.Items {
+		e.Items = append(e.Items, EnumItem{
+			Idx: item.Index,
+			Val: item.Value,
+		})
+		m := ItemMetadata{
+			Label:       item.Label,
+			Labels:      item.Labels,
+			Description: item.Description,
+			Deprecated:  item.Deprecated,
+			ReplacedBy:  item.ReplacedBy,
+			Aliases:     item.Aliases,
+			Attributes:  item.Attributes,
+		}
+		if !m.isZero() {
+			e.SetMetadata(item.Index, m)
+		}
+	}
+	if err := e.Validate(); err != nil {
+		return nil, api_error.NewError(fmt.Sprintf("invalid enum definition %v", d.Name), err.StatusCode(), err.Causes())
+	}
+	return e, nil
+}
+
+// Definition returns the definition of the enum under the given name, including all item metadata.
+func (e *Enum) Definition(name string) Definition {
+	def := Definition{
+		Name:    name,
+		Items:   make([]ItemDefinition, 0, len(e.Items)),
+		History: e.History(),
+	}
+	for _, item := range e.Items {
+		m := e.Metadata(item.Idx)
+		def.Items = append(def.Items, ItemDefinition{
+			Index:       item.Idx,
+			Value:       item.Val,
+			Label:       m.Label,
+			Labels:      m.Labels,
+			Description: m.Description,
+			Deprecated:  m.Deprecated,
+			ReplacedBy:  m.ReplacedBy,
+			Aliases:     m.Aliases,
+			Attributes:  m.Attributes,
+		})
+	}
+	return def
+}
+
+// Enums validates all definitions and returns the enums by name. All problems are reported in one error.
+func (defs Definitions) Enums() (map[string]*Enum, api_error.ApiErr) {
+	enums := make(map[string]*Enum, len(defs))
+	names := make(map[string]struct{}, len(defs))
+	causes := make([]any, 0)
+	for i, def := range defs {
+		name := strings.TrimSpace(def.Name)
+		if name == "" {
+			causes = append(causes, fmt.Sprintf("enum %v has no name", i))
+			continue
+		}
+		if _, ok := names[name]; ok {
+			causes = append(causes, fmt.Sprintf("duplicate enum name %v", name))
+			continue
+		}
+		names[name] = struct{}{}
+		e, err := def.Enum()
+		if err != nil {
+			for _, cause := range err.Causes() {
+				causes = append(causes, fmt.Sprintf("%v: %v", name, cause))
+			}
+			continue
+		}
+		enums[name] = e
+	}
+	if len(causes) > 0 {
+		return nil, api_error.NewError("invalid enum definitions", http.StatusUnprocessableEntity, causes)
+	}
+	return enums, nil
+}
+
+// Validate checks that the enum has no duplicate indexes, no case-insensitive duplicate values or aliases,
+// no empty values, only valid replacements and a consistent history.
+func (e *Enum) Validate() api_error.ApiErr {
+	causes := make([]any, 0)
+	indexes := make(map[int32]struct{}, len(e.Items))
+	values := make(map[string]string, len(e.Items))
+	for _, item := range e.Items {
+		if _, ok := indexes[item.Idx]; ok {
+			causes = append(causes, fmt.Sprintf("duplicate index %v", item.Idx))
+		}
+		indexes[item.Idx] = struct{}{}
+
+		if strings.TrimSpace(item.Val) == "" {
+			causes = append(causes, fmt.Sprintf("empty value at index %v", item.Idx))
+			continue
+		}
+		key := foldKey(item.Val)
+		if existing, ok := values[key]; ok {
+			causes = append(causes, fmt.Sprintf("duplicate value %v (conflicts with %v)", item.Val, existing))
+		}
+		values[key] = item.Val
+	}
+	causes = append(causes, e.validateMetadata()...)
+	causes = append(causes, e.validateHistory()...)
+	if len(causes) > 0 {
+		return api_error.NewError("invalid enum", http.StatusUnprocessableEntity, causes)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package enums
+
+import (
+	"embed"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed testdata/enums.json
+var testDefinitionsFS embed.FS
+
+func assertTestDefinitions(t *testing.T, enums map[string]*Enum) {
+	t.Helper()
+	assert.EqualValues(t, 2, len(enums))
+	assert.EqualValues(t, []string{"Basic", "Advanced"}, enums["AccountTypes"].Values())
+	idx, err := enums["OrderStatus"].AsIndex("shipped")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, idx)
+}
+
+func TestLoadFileJSON(t *testing.T) {
+	enums, err := LoadFile(filepath.Join("testdata", "enums.json"))
+	assert.Nil(t, err)
+	assertTestDefinitions(t, enums)
+}
+
+func TestLoadFileYAML(t *testing.T) {
+	enums, err := LoadFile(filepath.Join("testdata", "enums.yaml"))
+	assert.Nil(t, err)
+	assertTestDefinitions(t, enums)
+}
+
+func TestLoadFSEmbedded(t *testing.T) {
+	enums, err := LoadFS(testDefinitionsFS, "testdata/enums.json")
+	assert.Nil(t, err)
+	assertTestDefinitions(t, enums)
+}
+
+func TestLoadFileMissingFileReturnsError(t *testing.T) {
+	enums, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Nil(t, enums)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusInternalServerError, err.StatusCode())
+}
+
+func TestLoadFileUnsupportedExtensionReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enums.txt")
+	assert.Nil(t, os.WriteFile(path, []byte("{}"), 0o600))
+
+	enums, err := LoadFile(path)
+
+	assert.Nil(t, enums)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.StatusCode())
+}
+
+func TestDefinitionsFromJSONRejectsUnknownFields(t *testing.T) {
+	defs, err := DefinitionsFromJSON([]byte(`{"enums": [{"name": "A", "itmes": []}]}`))
+	assert.Nil(t, defs)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.StatusCode())
+}
+
+func TestDefinitionsFromYAMLRejectsUnknownFields(t *testing.T) {
+	defs, err := DefinitionsFromYAML([]byte("enums:\n  - name: A\n    itmes: []\n"))
+	assert.Nil(t, defs)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.StatusCode())
+}
+
+func TestDefinitionsFromYAMLEmptyReturnsNoDefinitions(t *testing.T) {
+	defs, err := DefinitionsFromYAML(nil)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, len(defs))
+}
+
+func TestDefinitionsEnumsReportsAllProblems(t *testing.T) {
+	defs := Definitions{
+		{Name: "A", Items: []ItemDefinition{{Index: 0, Value: "x"}, {Index: 0, Value: "y"}}},
+		{Name: "B", Items: []ItemDefinition{{Index: 0, Value: "Red"}, {Index: 1, Value: "RED"}, {Index: 2, Value: " "}}},
+		{Name: "A"},
+		{Name: ""},
+	}
+
+	enums, err := defs.Enums()
+
+	assert.Nil(t, enums)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, err.StatusCode())
+	assert.EqualValues(t, []any{
+		"A: duplicate index 0",
+		"B: duplicate value RED (conflicts with Red)",
+		"B: empty value at index 2",
+		"duplicate enum name A",
+		"enum 3 has no name",
+	}, err.Causes())
+}
+
+func TestValidateValidEnumReturnsNoError(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	assert.Nil(t, TestEnum1.Validate())
+	assert.Nil(t, EmptyEnum.Validate())
+}
+
+func TestLoadFileWithMetadata(t *testing.T) {
+	enums, err := LoadFile(filepath.Join("testdata", "metadata.yaml"))
+	assert.Nil(t, err)
+
+	colors := enums["Colors"]
+	assert.EqualValues(t, "Rot", colors.DisplayLabel(0, "de"))
+	assert.EqualValues(t, "#ff0000", colors.Metadata(0).Attributes["color"])
+	assert.EqualValues(t, "The color of grass", colors.Metadata(1).Description)
+	assert.EqualValues(t, []string{"Red", "Green"}, colors.ActiveValues())
+	item, _ := colors.ItemByValue("crimson")
+	assert.EqualValues(t, "Red", item.Val)
+	replacement, _ := colors.Replacement(2)
+	assert.EqualValues(t, "Green", replacement.Val)
+}
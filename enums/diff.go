@@ -0,0 +1,245 @@
+package enums
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+type DiffKind string
+
+const (
+	DiffEnumAdded   DiffKind = "enum added"
+	DiffEnumRemoved DiffKind = "enum removed"
+	DiffAdded       DiffKind = "added"
+	DiffRemoved     DiffKind = "removed"
+	DiffRenamed     DiffKind = "renamed"
+	DiffMerged      DiffKind = "merged"
+	DiffMoved       DiffKind = "moved"
+	DiffDeprecated  DiffKind = "deprecated"
+)
+
+// Difference is a single change between two definitions. Index and OldValue refer to the old item,
+// NewIndex and NewValue to the new one. Documented reports whether the new history covers the change;
+// additions and deprecations need no history entry and are always documented. Moves are documented by a
+// moved or renamed change with Into set to the new index. A removed enum cannot carry a history, so to document
+// its removal, keep its definition without items and record the removed items in its history.
+type Difference struct {
+	Enum       string   `json:"enum"`
+	Kind       DiffKind `json:"kind"`
+	Index      int32    `json:"index"`
+	OldValue   string   `json:"oldValue,omitempty"`
+	NewIndex   int32    `json:"newIndex"`
+	NewValue   string   `json:"newValue,omitempty"`
+	Documented bool     `json:"documented"`
+}
+
+func (d Difference) String() string {
+	var s string
+	switch d.Kind {
+	case DiffEnumAdded, DiffEnumRemoved:
+		s = fmt.Sprintf("%v: %v", d.Enum, d.Kind)
+	case DiffAdded:
+		s = fmt.Sprintf("%v: added %v (index %v)", d.Enum, d.NewValue, d.NewIndex)
+	case DiffRemoved:
+		s = fmt.Sprintf("%v: removed %v (index %v)", d.Enum, d.OldValue, d.Index)
+	case DiffRenamed:
+		s = fmt.Sprintf("%v: renamed %v to %v (index %v)", d.Enum, d.OldValue, d.NewValue, d.Index)
+	case DiffMerged:
+		s = fmt.Sprintf("%v: merged %v (index %v) into %v (index %v)", d.Enum, d.OldValue, d.Index, d.NewValue, d.NewIndex)
+	case DiffMoved:
+		s = fmt.Sprintf("%v: moved %v from index %v to %v", d.Enum, d.OldValue, d.Index, d.NewIndex)
+	case DiffDeprecated:
+		s = fmt.Sprintf("%v: deprecated %v (index %v)", d.Enum, d.OldValue, d.Index)
+	default:
+		s = fmt.Sprintf("%v: %v", d.Enum, d.Kind)
+	}
+	if !d.Documented {
+		s += " [not in history]"
+	}
+	return s
+}
+
+type Report struct {
+	Differences []Difference `json:"differences"`
+}
+
+func (r Report) IsEmpty() bool {
+	return len(r.Differences) == 0
+}
+
+// Undocumented returns the differences the new history does not account for, e.g. to fail a CI check.
+func (r Report) Undocumented() []Difference {
+	diffs := make([]Difference, 0)
+	for _, d := range r.Differences {
+		if !d.Documented {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// String lists one difference per line.
+func (r Report) String() string {
+	var sb strings.Builder
+	for _, d := range r.Differences {
+		sb.WriteString(d.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Compare reports the differences between two sets of definitions, ordered by enum name and index.
+func Compare(from, to Definitions) Report {
+	oldDefs := definitionsByName(from)
+	newDefs := definitionsByName(to)
+	names := make([]string, 0, len(oldDefs)+len(newDefs))
+	for name := range oldDefs {
+		names = append(names, name)
+	}
+	for name := range newDefs {
+		if _, ok := oldDefs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	report := Report{
+		Differences: make([]Difference, 0),
+	}
+	for _, name := range names {
+		o, inOld := oldDefs[name]
+		n, inNew := newDefs[name]
+		switch {
+		case !inOld:
+			report.Differences = append(report.Differences, Difference{Enum: name, Kind: DiffEnumAdded, Documented: true})
+		case !inNew:
+			report.Differences = append(report.Differences, Difference{Enum: name, Kind: DiffEnumRemoved})
+		default:
+			report.Differences = append(report.Differences, compareItems(name, o, n)...)
+		}
+	}
+	return report
+}
+
+// CompareFiles reads two definition files and reports the differences between them.
+func CompareFiles(oldPath, newPath string) (Report, api_error.ApiErr) {
+	from, err := ReadDefinitionsFile(oldPath)
+	if err != nil {
+		return Report{}, err
+	}
+	to, err := ReadDefinitionsFile(newPath)
+	if err != nil {
+		return Report{}, err
+	}
+	return Compare(from, to), nil
+}
+
+func definitionsByName(defs Definitions) map[string]Definition {
+	m := make(map[string]Definition, len(defs))
+	for _, def := range defs {
+		name := strings.TrimSpace(def.Name)
+		if _, ok := m[name]; !ok {
+			m[name] = def
+		}
+	}
+	return m
+}
+
+func compareItems(name string, from, to Definition) []Difference {
+	diffs := make([]Difference, 0)
+	newItems := make(map[int32]ItemDefinition, len(to.Items))
+	newValues := make(map[string]ItemDefinition, len(to.Items))
+	for _, item := range to.Items {
+		newItems[item.Index] = item
+		newValues[foldKey(item.Value)] = item
+	}
+	oldItems := make(map[int32]ItemDefinition, len(from.Items))
+	oldValues := make(map[string]struct{}, len(from.Items))
+	for _, item := range from.Items {
+		oldItems[item.Index] = item
+		oldValues[foldKey(item.Value)] = struct{}{}
+	}
+	current := &Enum{Items: definitionItems(to)}
+	current.SetHistory(to.History)
+	documented := func(kind ChangeKind, idx int32, val string) bool {
+		_, ok := current.lastChange(func(c Change) bool {
+			return c.Kind == kind && c.Index == idx && strings.EqualFold(c.Value, val)
+		})
+		return ok
+	}
+	documentedMove := func(idx int32, val string, to int32) bool {
+		_, ok := current.lastChange(func(c Change) bool {
+			target, ok := c.target()
+			return (c.Kind == ChangeMoved || c.Kind == ChangeRenamed) && c.Index == idx && strings.EqualFold(c.Value, val) &&
+				ok && target == to
+		})
+		return ok
+	}
+
+	for _, o := range from.Items {
+		d := Difference{Enum: name, Index: o.Index, OldValue: o.Value, NewIndex: o.Index}
+		if n, ok := newItems[o.Index]; ok {
+			switch {
+			case foldKey(n.Value) != foldKey(o.Value):
+				d.Kind = DiffRenamed
+				d.NewValue = n.Value
+				d.Documented = documented(ChangeRenamed, o.Index, o.Value)
+			case n.Deprecated && !o.Deprecated:
+				d.Kind = DiffDeprecated
+				d.NewValue = n.Value
+				d.Documented = true
+			default:
+				continue
+			}
+			diffs = append(diffs, d)
+			continue
+		}
+
+		merged, ok := current.lastChange(func(c Change) bool {
+			return c.Kind == ChangeMerged && c.Index == o.Index && c.Into != nil
+		})
+		if ok {
+			d.Kind = DiffMerged
+			d.Documented = true
+			if target, err := current.ResolveLegacyIndex(*merged.Into); err == nil {
+				d.NewIndex = target.Idx
+				d.NewValue = target.Val
+			}
+		} else if n, ok := newValues[foldKey(o.Value)]; ok {
+			d.Kind = DiffMoved
+			d.NewIndex = n.Index
+			d.NewValue = n.Value
+			d.Documented = documentedMove(o.Index, o.Value, n.Index)
+		} else {
+			d.Kind = DiffRemoved
+			d.Documented = documented(ChangeRemoved, o.Index, o.Value)
+		}
+		diffs = append(diffs, d)
+	}
+
+	for _, n := range to.Items {
+		if _, ok := oldItems[n.Index]; ok {
+			continue
+		}
+		if _, ok := oldValues[foldKey(n.Value)]; ok {
+			// reported as moved
+			continue
+		}
+		diffs = append(diffs, Difference{Enum: name, Kind: DiffAdded, Index: n.Index, NewIndex: n.Index, NewValue: n.Value, Documented: true})
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].Index < diffs[j].Index
+	})
+	return diffs
+}
+
+func definitionItems(def Definition) []EnumItem {
+	items := make([]EnumItem, 0, len(def.Items))
+	for _, item := range def.Items {
+		items = append(items, EnumItem{Idx: item.Index, Val: item.Value})
+	}
+	return items
+}
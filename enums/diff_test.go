@@ -0,0 +1,104 @@
+package enums
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareFilesReportsChanges(t *testing.T) {
+	report, err := CompareFiles("testdata/enums.yaml", "testdata/history.yaml")
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, []Difference{
+		{Enum: "AccountTypes", Kind: DiffRenamed, Index: 1, OldValue: "Advanced", NewIndex: 1, NewValue: "Premium", Documented: true},
+		{Enum: "OrderStatus", Kind: DiffMerged, Index: 1, OldValue: "Paid", NewIndex: 0, NewValue: "New", Documented: true},
+		{Enum: "OrderStatus", Kind: DiffAdded, Index: 3, NewIndex: 3, NewValue: "Cancelled", Documented: true},
+	}, report.Differences)
+	assert.Empty(t, report.Undocumented())
+}
+
+func TestCompareFilesUnchanged(t *testing.T) {
+	report, err := CompareFiles("testdata/enums.json", "testdata/enums.yaml")
+
+	assert.Nil(t, err)
+	assert.True(t, report.IsEmpty())
+	assert.EqualValues(t, "", report.String())
+}
+
+func TestCompareFilesMissingFileReturnsError(t *testing.T) {
+	_, err := CompareFiles("testdata/missing.yaml", "testdata/enums.yaml")
+
+	assert.NotNil(t, err)
+}
+
+func TestCompareReportsUndocumentedChanges(t *testing.T) {
+	from := Definitions{
+		{Name: "Colors", Items: []ItemDefinition{{Index: 0, Value: "Red"}, {Index: 1, Value: "Green"}, {Index: 2, Value: "Blue"}, {Index: 3, Value: "Pink"}}},
+		{Name: "Sizes", Items: []ItemDefinition{{Index: 0, Value: "S"}}},
+	}
+	to := Definitions{
+		{Name: "Colors", Items: []ItemDefinition{{Index: 0, Value: "Crimson"}, {Index: 1, Value: "Green", Deprecated: true}, {Index: 5, Value: "Blue"}}},
+		{Name: "Shapes", Items: []ItemDefinition{{Index: 0, Value: "Circle"}}},
+	}
+
+	report := Compare(from, to)
+
+	assert.EqualValues(t, []Difference{
+		{Enum: "Colors", Kind: DiffRenamed, Index: 0, OldValue: "Red", NewIndex: 0, NewValue: "Crimson"},
+		{Enum: "Colors", Kind: DiffDeprecated, Index: 1, OldValue: "Green", NewIndex: 1, NewValue: "Green", Documented: true},
+		{Enum: "Colors", Kind: DiffMoved, Index: 2, OldValue: "Blue", NewIndex: 5, NewValue: "Blue"},
+		{Enum: "Colors", Kind: DiffRemoved, Index: 3, OldValue: "Pink", NewIndex: 3},
+		{Enum: "Shapes", Kind: DiffEnumAdded, Documented: true},
+		{Enum: "Sizes", Kind: DiffEnumRemoved},
+	}, report.Differences)
+	assert.EqualValues(t, 4, len(report.Undocumented()))
+}
+
+func TestCompareDocumentsMovesAndEnumRemovals(t *testing.T) {
+	from := Definitions{
+		{Name: "Colors", Items: []ItemDefinition{{Index: 0, Value: "Red"}, {Index: 1, Value: "Green"}, {Index: 2, Value: "Blue"}}},
+		{Name: "Sizes", Items: []ItemDefinition{{Index: 0, Value: "S"}}},
+	}
+	to := Definitions{
+		{
+			Name:  "Colors",
+			Items: []ItemDefinition{{Index: 0, Value: "Red"}, {Index: 5, Value: "Green"}, {Index: 6, Value: "Blue"}},
+			History: []Change{
+				{Version: "2", Kind: ChangeMoved, Index: 1, Value: "Green", Into: new(int32(5))},
+				{Version: "2", Kind: ChangeRenamed, Index: 2, Value: "Blue", Into: new(int32(6))},
+			},
+		},
+		{Name: "Sizes", History: []Change{{Version: "2", Kind: ChangeRemoved, Index: 0, Value: "S"}}},
+	}
+
+	report := Compare(from, to)
+
+	assert.EqualValues(t, []Difference{
+		{Enum: "Colors", Kind: DiffMoved, Index: 1, OldValue: "Green", NewIndex: 5, NewValue: "Green", Documented: true},
+		{Enum: "Colors", Kind: DiffMoved, Index: 2, OldValue: "Blue", NewIndex: 6, NewValue: "Blue", Documented: true},
+		{Enum: "Sizes", Kind: DiffRemoved, Index: 0, OldValue: "S", NewIndex: 0, Documented: true},
+	}, report.Differences)
+	assert.Empty(t, report.Undocumented())
+}
+
+func TestCompareMoveToOtherIndexIsNotDocumented(t *testing.T) {
+	from := Definitions{{Name: "Colors", Items: []ItemDefinition{{Index: 1, Value: "Green"}}}}
+	to := Definitions{{
+		Name:    "Colors",
+		Items:   []ItemDefinition{{Index: 7, Value: "Green"}},
+		History: []Change{{Version: "2", Kind: ChangeMoved, Index: 1, Value: "Green", Into: new(int32(5))}},
+	}}
+
+	assert.EqualValues(t, 1, len(Compare(from, to).Undocumented()))
+}
+
+func TestReportString(t *testing.T) {
+	report, _ := CompareFiles("testdata/enums.yaml", "testdata/history.yaml")
+	report.Differences = append(report.Differences, Difference{Enum: "Sizes", Kind: DiffRemoved, Index: 2, OldValue: "XL"})
+
+	assert.EqualValues(t, "AccountTypes: renamed Advanced to Premium (index 1)\n"+
+		"OrderStatus: merged Paid (index 1) into New (index 0)\n"+
+		"OrderStatus: added Cancelled (index 3)\n"+
+		"Sizes: removed XL (index 2) [not in history]\n", report.String())
+}
@@ -3,12 +3,14 @@ package enums
 import (
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/johannes-kuhfuss/services_utils/api_error"
 )
 
 // Usage: var AccountTypes = Enum{[]EnumItem{{0, "Basic"}, {1, "Advanced"}}}
+//
+// Lookups by index and value use maps that are built on first use and are safe for concurrent reads. The maps,
+// item metadata and history belong to the *Enum, a copy of an Enum value starts without metadata and history.
 
 type EnumItem struct {
 	Idx int32
@@ -61,22 +63,19 @@ func (e *Enum) FromMap(m map[int32]string) {
 	sort.Slice(e.Items, func(i, j int) bool {
 		return e.Items[i].Idx < e.Items[j].Idx
 	})
+	e.Reindex()
 }
 
 func (e *Enum) ItemByValue(v string) (*EnumItem, api_error.ApiErr) {
-	for i := range e.Items {
-		if strings.EqualFold(v, e.Items[i].Val) {
-			return &e.Items[i], nil
-		}
+	if pos := e.findValue(v); pos >= 0 {
+		return &e.Items[pos], nil
 	}
 	return nil, api_error.NewNotFoundError(fmt.Sprintf("No item with value %v found", v))
 }
 
 func (e *Enum) ItemByIndex(i int32) (*EnumItem, api_error.ApiErr) {
-	for iItem := range e.Items {
-		if e.Items[iItem].Idx == i {
-			return &e.Items[iItem], nil
-		}
+	if pos := e.findIndex(i); pos >= 0 {
+		return &e.Items[pos], nil
 	}
 	return nil, api_error.NewNotFoundError(fmt.Sprintf("No item with index %v found", i))
 }
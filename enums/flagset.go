@@ -0,0 +1,256 @@
+package enums
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	var Permissions = Enum{Items: []EnumItem{{Idx: 0, Val: "Read"}, {Idx: 1, Val: "Write"}}}
+//	type permissions struct{}
+//	func (permissions) Enum() *enums.Enum { return &Permissions }
+//	type PermissionSet = enums.FlagSet[permissions]
+//
+// Each item index is a bit position, so flag enums may only use indexes 0-63. A FlagSet renders as "Read|Write",
+// marshals to a JSON array of names and is stored in databases as its bitmask.
+
+const (
+	maxFlagIndex  = 63
+	flagSeparator = "|"
+)
+
+type FlagSet[B Binding] struct {
+	bits uint64
+}
+
+// ValidateFlags checks that all item indexes can be used as bit positions.
+func (e *Enum) ValidateFlags() api_error.ApiErr {
+	causes := make([]any, 0)
+	for _, item := range e.Items {
+		if item.Idx < 0 || item.Idx > maxFlagIndex {
+			causes = append(causes, fmt.Sprintf("index %v of %v is outside 0-%v", item.Idx, item.Val, maxFlagIndex))
+		}
+	}
+	if len(causes) > 0 {
+		return api_error.NewError("invalid flag enum", http.StatusUnprocessableEntity, causes)
+	}
+	return nil
+}
+
+func NewFlagSet[B Binding](idx ...int32) (FlagSet[B], api_error.ApiErr) {
+	return FlagSet[B]{}.Add(idx...)
+}
+
+// FlagSetFromBits returns the set for a bitmask; bits without an item are rejected.
+func FlagSetFromBits[B Binding](mask uint64) (FlagSet[B], api_error.ApiErr) {
+	var f FlagSet[B]
+	for rest := mask; rest != 0; rest &= rest - 1 {
+		if _, err := f.enum().ItemByIndex(int32(bits.TrailingZeros64(rest))); err != nil {
+			return FlagSet[B]{}, err
+		}
+	}
+	f.bits = mask
+	return f, nil
+}
+
+// ParseFlagSet parses names separated by "|" or ",", e.g. "Read|Write". An empty string is the empty set.
+func ParseFlagSet[B Binding](s string) (FlagSet[B], api_error.ApiErr) {
+	var f FlagSet[B]
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '|' || r == ','
+	})
+	for _, part := range parts {
+		name := strings.TrimSpace(part)
+		if name == "" {
+			continue
+		}
+		item, err := f.enum().ItemByValue(name)
+		if err != nil {
+			return FlagSet[B]{}, err
+		}
+		if f, err = f.Add(item.Idx); err != nil {
+			return FlagSet[B]{}, err
+		}
+	}
+	return f, nil
+}
+
+func (f FlagSet[B]) enum() *Enum {
+	var b B
+	return b.Enum()
+}
+
+func flagBit(idx int32) (uint64, api_error.ApiErr) {
+	if idx < 0 || idx > maxFlagIndex {
+		return 0, api_error.NewValidationError(fmt.Sprintf("flag index %v is outside 0-%v", idx, maxFlagIndex))
+	}
+	return 1 << uint(idx), nil
+}
+
+func (f FlagSet[B]) Bits() uint64 {
+	return f.bits
+}
+
+func (f FlagSet[B]) IsEmpty() bool {
+	return f.bits == 0
+}
+
+func (f FlagSet[B]) Contains(idx int32) bool {
+	bit, err := flagBit(idx)
+	return err == nil && f.bits&bit != 0
+}
+
+// ContainsAll reports whether all flags of other are set in f.
+func (f FlagSet[B]) ContainsAll(other FlagSet[B]) bool {
+	return f.bits&other.bits == other.bits
+}
+
+// Add returns a copy of f with the given flags set. On error f is returned unchanged.
+func (f FlagSet[B]) Add(idx ...int32) (FlagSet[B], api_error.ApiErr) {
+	result := f
+	for _, i := range idx {
+		bit, err := flagBit(i)
+		if err != nil {
+			return f, err
+		}
+		if _, err := f.enum().ItemByIndex(i); err != nil {
+			return f, err
+		}
+		result.bits |= bit
+	}
+	return result, nil
+}
+
+// Remove returns a copy of f with the given flags cleared. Indexes outside 0-63 are ignored.
+func (f FlagSet[B]) Remove(idx ...int32) FlagSet[B] {
+	for _, i := range idx {
+		if bit, err := flagBit(i); err == nil {
+			f.bits &^= bit
+		}
+	}
+	return f
+}
+
+// Union combines the flags of f and other.
+func (f FlagSet[B]) Union(other FlagSet[B]) FlagSet[B] {
+	return FlagSet[B]{bits: f.bits | other.bits}
+}
+
+func (f FlagSet[B]) Intersect(other FlagSet[B]) FlagSet[B] {
+	return FlagSet[B]{bits: f.bits & other.bits}
+}
+
+// Indexes returns the set flags in ascending order.
+func (f FlagSet[B]) Indexes() []int32 {
+	indexes := make([]int32, 0, bits.OnesCount64(f.bits))
+	for rest := f.bits; rest != 0; rest &= rest - 1 {
+		indexes = append(indexes, int32(bits.TrailingZeros64(rest)))
+	}
+	return indexes
+}
+
+// Names returns the values of the set flags in ascending index order.
+func (f FlagSet[B]) Names() []string {
+	names := make([]string, 0, bits.OnesCount64(f.bits))
+	for _, idx := range f.Indexes() {
+		if val, err := f.enum().AsValue(idx); err == nil {
+			names = append(names, val)
+		}
+	}
+	return names
+}
+
+func (f FlagSet[B]) String() string {
+	return strings.Join(f.Names(), flagSeparator)
+}
+
+func (f FlagSet[B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Names())
+}
+
+// UnmarshalJSON accepts an array of names or indexes, or a string such as "Read|Write".
+func (f *FlagSet[B]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*f = FlagSet[B]{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := ParseFlagSet[B](s)
+		if err != nil {
+			return err
+		}
+		*f = parsed
+		return nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return api_error.NewValidationError(fmt.Sprintf("invalid flag set %s", data))
+	}
+	var result FlagSet[B]
+	for _, elem := range elems {
+		var name string
+		var idx int32
+		var err api_error.ApiErr
+		if json.Unmarshal(elem, &name) == nil {
+			var item *EnumItem
+			if item, err = result.enum().ItemByValue(name); err == nil {
+				result, err = result.Add(item.Idx)
+			}
+		} else if json.Unmarshal(elem, &idx) == nil {
+			result, err = result.Add(idx)
+		} else {
+			err = api_error.NewValidationError(fmt.Sprintf("invalid flag %s", elem))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*f = result
+	return nil
+}
+
+func (f FlagSet[B]) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+func (f *FlagSet[B]) UnmarshalText(text []byte) error {
+	parsed, err := ParseFlagSet[B](string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner for bitmask columns.
+func (f *FlagSet[B]) Scan(src any) error {
+	switch s := src.(type) {
+	case nil:
+		*f = FlagSet[B]{}
+		return nil
+	case int64:
+		parsed, err := FlagSetFromBits[B](uint64(s))
+		if err != nil {
+			return err
+		}
+		*f = parsed
+		return nil
+	default:
+		return api_error.NewValidationError(fmt.Sprintf("cannot scan %T into flag set", src))
+	}
+}
+
+// Value implements driver.Valuer and stores the bitmask.
+func (f FlagSet[B]) Value() (driver.Value, error) {
+	return int64(f.bits), nil
+}
@@ -0,0 +1,165 @@
+package enums
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+	"github.com/stretchr/testify/assert"
+)
+
+var permissionsEnum = Enum{
+	Items: []EnumItem{
+		{Idx: 0, Val: "Read"},
+		{Idx: 1, Val: "Write"},
+		{Idx: 2, Val: "Delete"},
+		{Idx: 63, Val: "Admin"},
+	},
+}
+
+type permissions struct{}
+
+func (permissions) Enum() *Enum { return &permissionsEnum }
+
+type permissionSet = FlagSet[permissions]
+
+func TestFlagSetAddContainsRemove(t *testing.T) {
+	f, err := NewFlagSet[permissions](0, 2)
+	assert.Nil(t, err)
+	assert.True(t, f.Contains(0))
+	assert.False(t, f.Contains(1))
+	assert.True(t, f.Contains(2))
+	assert.EqualValues(t, 0b101, f.Bits())
+
+	f, err = f.Add(63)
+	assert.Nil(t, err)
+	assert.True(t, f.Contains(63))
+	assert.EqualValues(t, []int32{0, 2, 63}, f.Indexes())
+
+	f = f.Remove(0, 63, 99)
+	assert.EqualValues(t, []int32{2}, f.Indexes())
+	assert.False(t, f.IsEmpty())
+	assert.True(t, f.Remove(2).IsEmpty())
+}
+
+func TestFlagSetAddOutOfRangeReturnsValidationError(t *testing.T) {
+	f, _ := NewFlagSet[permissions](0)
+
+	for _, idx := range []int32{-1, 64} {
+		result, err := f.Add(1, idx)
+		assert.NotNil(t, err)
+		assert.EqualValues(t, http.StatusUnprocessableEntity, err.StatusCode())
+		assert.EqualValues(t, f, result)
+	}
+	assert.False(t, f.Contains(64))
+}
+
+func TestFlagSetAddUnknownReturnsNotFound(t *testing.T) {
+	_, err := NewFlagSet[permissions](5)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestFlagSetCombine(t *testing.T) {
+	a, _ := NewFlagSet[permissions](0, 1)
+	b, _ := NewFlagSet[permissions](1, 2)
+
+	assert.EqualValues(t, []int32{0, 1, 2}, a.Union(b).Indexes())
+	assert.EqualValues(t, []int32{1}, a.Intersect(b).Indexes())
+	assert.True(t, a.Union(b).ContainsAll(a))
+	assert.False(t, a.ContainsAll(b))
+}
+
+func TestFlagSetFromBits(t *testing.T) {
+	f, err := FlagSetFromBits[permissions](1<<63 | 0b11)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"Read", "Write", "Admin"}, f.Names())
+
+	_, err = FlagSetFromBits[permissions](1 << 10)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestFlagSetStringAndParse(t *testing.T) {
+	f, _ := NewFlagSet[permissions](1, 0)
+	assert.EqualValues(t, "Read|Write", f.String())
+
+	parsed, err := ParseFlagSet[permissions]("write | READ,delete")
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Read|Write|Delete", parsed.String())
+
+	empty, err := ParseFlagSet[permissions]("")
+	assert.Nil(t, err)
+	assert.True(t, empty.IsEmpty())
+
+	_, err = ParseFlagSet[permissions]("Read|Execute")
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestFlagSetJSON(t *testing.T) {
+	type dto struct {
+		Permissions permissionSet `json:"permissions"`
+	}
+	f, _ := NewFlagSet[permissions](0, 1)
+
+	data, err := json.Marshal(dto{Permissions: f})
+	assert.Nil(t, err)
+	assert.EqualValues(t, `{"permissions":["Read","Write"]}`, string(data))
+
+	var fromArray, fromString, fromNull dto
+	assert.Nil(t, json.Unmarshal([]byte(`{"permissions":["read", 2]}`), &fromArray))
+	assert.EqualValues(t, []int32{0, 2}, fromArray.Permissions.Indexes())
+	assert.Nil(t, json.Unmarshal([]byte(`{"permissions":"Write|Delete"}`), &fromString))
+	assert.EqualValues(t, []int32{1, 2}, fromString.Permissions.Indexes())
+	assert.Nil(t, json.Unmarshal([]byte(`{"permissions":null}`), &fromNull))
+	assert.True(t, fromNull.Permissions.IsEmpty())
+
+	data, _ = json.Marshal(dto{})
+	assert.EqualValues(t, `{"permissions":[]}`, string(data))
+}
+
+func TestFlagSetUnmarshalJSONInvalidReturnsErrors(t *testing.T) {
+	var f permissionSet
+	var apiErr api_error.ApiErr
+
+	assert.ErrorAs(t, json.Unmarshal([]byte(`[64]`), &f), &apiErr)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, apiErr.StatusCode())
+	assert.ErrorAs(t, json.Unmarshal([]byte(`["Execute"]`), &f), &apiErr)
+	assert.EqualValues(t, http.StatusNotFound, apiErr.StatusCode())
+	assert.ErrorAs(t, json.Unmarshal([]byte(`[true]`), &f), &apiErr)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, apiErr.StatusCode())
+	assert.ErrorAs(t, json.Unmarshal([]byte(`{}`), &f), &apiErr)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, apiErr.StatusCode())
+}
+
+func TestFlagSetText(t *testing.T) {
+	var f permissionSet
+	assert.Nil(t, f.UnmarshalText([]byte("Read|Delete")))
+	text, err := f.MarshalText()
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Read|Delete", string(text))
+	assert.NotNil(t, f.UnmarshalText([]byte("Execute")))
+}
+
+func TestFlagSetSQL(t *testing.T) {
+	f, _ := NewFlagSet[permissions](0, 2)
+	val, err := f.Value()
+	assert.Nil(t, err)
+	assert.EqualValues(t, int64(5), val)
+
+	var scanned permissionSet
+	assert.Nil(t, scanned.Scan(int64(5)))
+	assert.EqualValues(t, f, scanned)
+	assert.Nil(t, scanned.Scan(nil))
+	assert.True(t, scanned.IsEmpty())
+	assert.NotNil(t, scanned.Scan(int64(8)))
+	assert.NotNil(t, scanned.Scan("Read"))
+}
+
+func TestValidateFlags(t *testing.T) {
+	assert.Nil(t, permissionsEnum.ValidateFlags())
+
+	e := Enum{Items: []EnumItem{{Idx: -1, Val: "a"}, {Idx: 64, Val: "b"}}}
+	err := e.ValidateFlags()
+	assert.NotNil(t, err)
+	assert.EqualValues(t, []any{"index -1 of a is outside 0-63", "index 64 of b is outside 0-63"}, err.Causes())
+}
@@ -0,0 +1,283 @@
+package enums
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+type GenerateOptions struct {
+	// Package is the package clause of the generated file.
+	Package string
+	// TypeName is the generated Go type, e.g. AccountType.
+	TypeName string
+	// VarName is the generated Typed variable; it defaults to the definition name, e.g. AccountTypes.
+	VarName string
+}
+
+type generateConst struct {
+	Name     string
+	Index    int32
+	Item     EnumItem
+	Metadata ItemMetadata
+}
+
+type generateData struct {
+	Package  string
+	TypeName string
+	VarName  string
+	Consts   []generateConst
+	History  []Change
+	// Setup is set if metadata or history are set after creating the enum.
+	Setup bool
+}
+
+var generateTemplate = template.Must(template.New("enum").Funcs(template.FuncMap{"item": itemLiteral, "metadata": metadataLiteral, "change": changeLiteral}).Parse(`// Code generated by enumgen; DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+	"github.com/johannes-kuhfuss/services_utils/enums"
+)
+
+type {{ .TypeName }} int32
+
+const (
+{{- range .Consts }}
+{{- if .Metadata.Deprecated }}
+	// Deprecated: {{ .Name }} is kept for existing data only.
+{{- end }}
+	{{ .Name }} {{ $.TypeName }} = {{ .Index }}
+{{- end }}
+)
+
+{{- if .Setup }}
+var {{ .VarName }} = enums.NewTyped[{{ .TypeName }}](func() *enums.Enum {
+	e := &enums.Enum{
+		Items: []enums.EnumItem{
+{{- range .Consts }}
+			{{ item .Item }},
+{{- end }}
+		},
+	}
+{{- range $c := .Consts }}
+{{- with metadata $c.Metadata }}
+	e.SetMetadata({{ $c.Index }}, {{ . }})
+{{- end }}
+{{- end }}
+{{- with .History }}
+	e.SetHistory([]enums.Change{
+{{- range . }}
+		{{ change . }},
+{{- end }}
+	})
+{{- end }}
+	return e
+}())
+{{- else }}
+var {{ .VarName }} = enums.NewTyped[{{ .TypeName }}](&enums.Enum{
+	Items: []enums.EnumItem{
+{{- range .Consts }}
+		{{ item .Item }},
+{{- end }}
+	},
+})
+{{- end }}
+
+func (v {{ .TypeName }}) String() string {
+	return {{ .VarName }}.String(v)
+}
+
+func Parse{{ .TypeName }}(s string) ({{ .TypeName }}, api_error.ApiErr) {
+	return {{ .VarName }}.Parse(s)
+}
+
+func {{ .TypeName }}Values() []{{ .TypeName }} {
+	return {{ .VarName }}.Values()
+}
+`))
+
+// Generate renders a Go source file with a dedicated type, constants, String, Parse and Values for the definition.
+func Generate(def Definition, opts GenerateOptions) ([]byte, api_error.ApiErr) {
+	e, err := def.Enum()
+	if err != nil {
+		return nil, err
+	}
+	data := generateData{
+		Package:  opts.Package,
+		TypeName: opts.TypeName,
+		VarName:  opts.VarName,
+		Consts:   make([]generateConst, 0, len(e.Items)),
+		History:  e.History(),
+	}
+	if data.VarName == "" {
+		data.VarName = def.Name
+	}
+	for _, name := range []string{data.Package, data.TypeName, data.VarName} {
+		if !token.IsIdentifier(name) {
+			return nil, api_error.NewBadRequestError(fmt.Sprintf("%q is not a valid Go identifier", name))
+		}
+	}
+	if data.TypeName == data.VarName {
+		return nil, api_error.NewBadRequestError(fmt.Sprintf("type and variable must have different names, both are %v", data.TypeName))
+	}
+
+	suffixes, err := constSuffixes(e.Items)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range e.Items {
+		c := generateConst{
+			Name:     data.TypeName + suffixes[i],
+			Index:    item.Idx,
+			Item:     item,
+			Metadata: e.Metadata(item.Idx),
+		}
+		data.Setup = data.Setup || !c.Metadata.isZero()
+		data.Consts = append(data.Consts, c)
+	}
+	data.Setup = data.Setup || len(data.History) > 0
+
+	var buf bytes.Buffer
+	if err := generateTemplate.Execute(&buf, data); err != nil {
+		return nil, api_error.NewInternalServerError("could not render enum", err)
+	}
+	src, fmtErr := format.Source(buf.Bytes())
+	if fmtErr != nil {
+		return nil, api_error.NewInternalServerError("could not format generated enum", fmtErr)
+	}
+	return src, nil
+}
+
+// itemLiteral renders an EnumItem composite literal.
+func itemLiteral(item EnumItem) string {
+	return fmt.Sprintf("{Idx: %d, Val: %q}", item.Idx, item.Val)
+}
+
+// metadataLiteral renders an ItemMetadata composite literal with all fields that are set, or nothing if none is.
+func metadataLiteral(m ItemMetadata) string {
+	if m.isZero() {
+		return ""
+	}
+	fields := make([]string, 0)
+	if m.Label != "" {
+		fields = append(fields, fmt.Sprintf("Label: %q", m.Label))
+	}
+	if len(m.Labels) > 0 {
+		labels := make(map[string]any, len(m.Labels))
+		for lang, label := range m.Labels {
+			labels[lang] = label
+		}
+		fields = append(fields, "Labels: map[string]string"+strings.TrimPrefix(goLiteral(labels), "map[string]any"))
+	}
+	if m.Description != "" {
+		fields = append(fields, fmt.Sprintf("Description: %q", m.Description))
+	}
+	if m.Deprecated {
+		fields = append(fields, "Deprecated: true")
+	}
+	if m.ReplacedBy != nil {
+		fields = append(fields, fmt.Sprintf("ReplacedBy: new(int32(%d))", *m.ReplacedBy))
+	}
+	if len(m.Aliases) > 0 {
+		aliases := make([]string, 0, len(m.Aliases))
+		for _, alias := range m.Aliases {
+			aliases = append(aliases, fmt.Sprintf("%q", alias))
+		}
+		fields = append(fields, "Aliases: []string{"+strings.Join(aliases, ", ")+"}")
+	}
+	if len(m.Attributes) > 0 {
+		fields = append(fields, "Attributes: "+goLiteral(m.Attributes))
+	}
+	return "enums.ItemMetadata{" + strings.Join(fields, ", ") + "}"
+}
+
+// changeLiteral renders a Change composite literal.
+func changeLiteral(c Change) string {
+	fields := []string{
+		fmt.Sprintf("Version: %q", c.Version),
+		"Kind: enums.Change" + identifier(string(c.Kind)),
+		fmt.Sprintf("Index: %d", c.Index),
+		fmt.Sprintf("Value: %q", c.Value),
+	}
+	if c.Into != nil {
+		fields = append(fields, fmt.Sprintf("Into: new(int32(%d))", *c.Into))
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// goLiteral renders values decoded from JSON or YAML as Go source.
+func goLiteral(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", val)
+	case float64:
+		return fmt.Sprintf("float64(%v)", val)
+	case []any:
+		elems := make([]string, 0, len(val))
+		for _, elem := range val {
+			elems = append(elems, goLiteral(elem))
+		}
+		return "[]any{" + strings.Join(elems, ", ") + "}"
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		elems := make([]string, 0, len(val))
+		for _, key := range keys {
+			elems = append(elems, fmt.Sprintf("%q: %v", key, goLiteral(val[key])))
+		}
+		return "map[string]any{" + strings.Join(elems, ", ") + "}"
+	default:
+		return fmt.Sprintf("%#v", val)
+	}
+}
+
+// constSuffixes returns the names of the generated constants without the type name. Values without letters or
+// digits and values that generate the same name are rejected.
+func constSuffixes(items []EnumItem) ([]string, api_error.ApiErr) {
+	suffixes := make([]string, 0, len(items))
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		suffix := identifier(item.Val)
+		if suffix == "" {
+			return nil, api_error.NewValidationError(fmt.Sprintf("value %q contains no letters or digits to generate a constant name", item.Val))
+		}
+		if existing, ok := values[suffix]; ok {
+			return nil, api_error.NewValidationError(fmt.Sprintf("values %v and %v both generate constant suffix %v", existing, item.Val, suffix))
+		}
+		values[suffix] = item.Val
+		suffixes = append(suffixes, suffix)
+	}
+	return suffixes, nil
+}
+
+// identifier turns a value such as "in progress" or "two-factor" into "InProgress" or "TwoFactor".
+func identifier(val string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range val {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
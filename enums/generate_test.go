@@ -0,0 +1,181 @@
+package enums
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var generateDefinition = Definition{
+	Name: "OrderStatuses",
+	Items: []ItemDefinition{
+		{Index: 0, Value: "new"},
+		{Index: 1, Value: "in progress"},
+		{Index: 2, Value: "two-factor pending"},
+	},
+}
+
+const generatedOrderStatus = `// Code generated by enumgen; DO NOT EDIT.
+
+package orders
+
+import (
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+	"github.com/johannes-kuhfuss/services_utils/enums"
+)
+
+type OrderStatus int32
+
+const (
+	OrderStatusNew              OrderStatus = 0
+	OrderStatusInProgress       OrderStatus = 1
+	OrderStatusTwoFactorPending OrderStatus = 2
+)
+
+var OrderStatuses = enums.NewTyped[OrderStatus](&enums.Enum{
+	Items: []enums.EnumItem{
+		{Idx: 0, Val: "new"},
+		{Idx: 1, Val: "in progress"},
+		{Idx: 2, Val: "two-factor pending"},
+	},
+})
+
+func (v OrderStatus) String() string {
+	return OrderStatuses.String(v)
+}
+
+func ParseOrderStatus(s string) (OrderStatus, api_error.ApiErr) {
+	return OrderStatuses.Parse(s)
+}
+
+func OrderStatusValues() []OrderStatus {
+	return OrderStatuses.Values()
+}
+`
+
+func TestGenerateRendersTypedEnum(t *testing.T) {
+	src, err := Generate(generateDefinition, GenerateOptions{
+		Package:  "orders",
+		TypeName: "OrderStatus",
+	})
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, generatedOrderStatus, string(src))
+}
+
+func TestGenerateInvalidIdentifierReturnsError(t *testing.T) {
+	src, err := Generate(generateDefinition, GenerateOptions{
+		Package:  "orders",
+		TypeName: "Order Status",
+	})
+
+	assert.Nil(t, src)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.StatusCode())
+}
+
+func TestGenerateSameTypeAndVarReturnsError(t *testing.T) {
+	src, err := Generate(generateDefinition, GenerateOptions{
+		Package:  "orders",
+		TypeName: "OrderStatuses",
+	})
+
+	assert.Nil(t, src)
+	assert.NotNil(t, err)
+}
+
+func TestGenerateConflictingConstantsReturnsError(t *testing.T) {
+	def := Definition{
+		Name: "Modes",
+		Items: []ItemDefinition{
+			{Index: 0, Value: "read-only"},
+			{Index: 1, Value: "read only"},
+		},
+	}
+
+	src, err := Generate(def, GenerateOptions{Package: "modes", TypeName: "Mode"})
+
+	assert.Nil(t, src)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, err.StatusCode())
+}
+
+func TestGenerateValueWithoutLettersReturnsError(t *testing.T) {
+	def := Definition{
+		Name:  "Operators",
+		Items: []ItemDefinition{{Index: 0, Value: "plus"}, {Index: 1, Value: "+"}},
+	}
+
+	src, err := Generate(def, GenerateOptions{Package: "ops", TypeName: "Operator"})
+
+	assert.Nil(t, src)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, err.StatusCode())
+	assert.Contains(t, err.Message(), `"+"`)
+}
+
+func TestGenerateInvalidDefinitionReturnsError(t *testing.T) {
+	def := Definition{
+		Name:  "Modes",
+		Items: []ItemDefinition{{Index: 0, Value: ""}},
+	}
+
+	src, err := Generate(def, GenerateOptions{Package: "modes", TypeName: "Mode"})
+
+	assert.Nil(t, src)
+	assert.NotNil(t, err)
+}
+
+func TestIdentifier(t *testing.T) {
+	assert.EqualValues(t, "InProgress", identifier("in progress"))
+	assert.EqualValues(t, "TwoFactor", identifier("two-factor"))
+	assert.EqualValues(t, "Größe", identifier("größe"))
+	assert.EqualValues(t, "2FA", identifier("2FA"))
+}
+
+func TestGenerateRendersMetadata(t *testing.T) {
+	def := Definition{
+		Name: "Colors",
+		Items: []ItemDefinition{
+			{
+				Index:      0,
+				Value:      "Red",
+				Label:      "Red color",
+				Labels:     map[string]string{"de": "Rot", "al": "E kuqe"},
+				Aliases:    []string{"crimson"},
+				Attributes: map[string]any{"order": float64(2), "tags": []any{"warm", true}},
+			},
+			{Index: 1, Value: "Olive", Description: "Dark green", Deprecated: true, ReplacedBy: new(int32(0))},
+		},
+	}
+
+	src, err := Generate(def, GenerateOptions{Package: "colors", TypeName: "Color"})
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(src), "\t\t\t{Idx: 0, Val: \"Red\"},\n\t\t\t{Idx: 1, Val: \"Olive\"},\n")
+	assert.Contains(t, string(src), `e.SetMetadata(0, enums.ItemMetadata{Label: "Red color", Labels: map[string]string{"al": "E kuqe", "de": "Rot"}, Aliases: []string{"crimson"}, Attributes: map[string]any{"order": float64(2), "tags": []any{"warm", true}}})`)
+	assert.Contains(t, string(src), `e.SetMetadata(1, enums.ItemMetadata{Description: "Dark green", Deprecated: true, ReplacedBy: new(int32(0))})`)
+	assert.Contains(t, string(src), "// Deprecated: ColorOlive is kept for existing data only.\n\tColorOlive ")
+}
+
+func TestGenerateRendersHistory(t *testing.T) {
+	def := Definition{
+		Name:  "AccountTypes",
+		Items: []ItemDefinition{{Index: 0, Value: "Basic"}, {Index: 1, Value: "Premium"}},
+		History: []Change{
+			{Version: "2", Kind: ChangeRenamed, Index: 1, Value: "Advanced"},
+			{Version: "3", Kind: ChangeMerged, Index: 2, Value: "Gold", Into: new(int32(1))},
+		},
+	}
+
+	src, err := Generate(def, GenerateOptions{Package: "accounts", TypeName: "AccountType"})
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(src), "\te.SetHistory([]enums.Change{\n"+
+		"\t\t{Version: \"2\", Kind: enums.ChangeRenamed, Index: 1, Value: \"Advanced\"},\n"+
+		"\t\t{Version: \"3\", Kind: enums.ChangeMerged, Index: 2, Value: \"Gold\", Into: new(int32(1))},\n"+
+		"\t})\n"+
+		"\treturn e\n"+
+		"}())\n")
+}
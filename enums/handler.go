@@ -0,0 +1,139 @@
+package enums
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	mux.Handle("/enums/", http.StripPrefix("/enums", enums.Handler()))
+//	mux.Handle("GET /enums/{name}", enums.Handler())
+//
+// GET /enums returns all registered enums, GET /enums/{name} a single one. Responses carry an ETag,
+// so clients can revalidate with If-None-Match and get 304 Not Modified while the enums are unchanged.
+
+type enumResponse struct {
+	Definition
+	Values []string         `json:"values"`
+	Map    map[int32]string `json:"map"`
+}
+
+type enumsResponse struct {
+	Enums []enumResponse `json:"enums"`
+}
+
+func newEnumResponse(name string, e *Enum) enumResponse {
+	def := e.Definition(name)
+	for i := range def.Items {
+		def.Items[i].Attributes = encodableAttributes(def.Items[i].Attributes)
+	}
+	return enumResponse{
+		Definition: def,
+		Values:     e.Values(),
+		Map:        e.AsMap(),
+	}
+}
+
+// encodableAttributes returns the attributes with values that cannot be encoded as JSON, such as functions
+// or NaN, replaced by their text, so that one attribute does not fail the whole response.
+func encodableAttributes(attrs map[string]any) map[string]any {
+	var encodable map[string]any
+	for key, value := range attrs {
+		if _, err := json.Marshal(value); err == nil {
+			continue
+		}
+		if encodable == nil {
+			encodable = maps.Clone(attrs)
+		}
+		encodable[key] = fmt.Sprint(value)
+	}
+	if encodable == nil {
+		return attrs
+	}
+	return encodable
+}
+
+// Handler serves the enums of the default registry.
+func Handler() http.Handler {
+	return defaultRegistry.Handler()
+}
+
+// Handler serves the registered enums as JSON. The enum name is taken from the {name} path value or,
+// if the pattern has none, from the request path after http.StripPrefix.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, api_error.NewError("method not allowed", http.StatusMethodNotAllowed, nil))
+			return
+		}
+
+		name := req.PathValue("name")
+		if name == "" {
+			name = strings.Trim(req.URL.Path, "/")
+		}
+
+		var body any
+		if name == "" {
+			all := enumsResponse{
+				Enums: make([]enumResponse, 0),
+			}
+			for _, n := range r.Names() {
+				if e, err := r.Lookup(n); err == nil {
+					all.Enums = append(all.Enums, newEnumResponse(n, e))
+				}
+			}
+			body = all
+		} else {
+			e, err := r.Lookup(name)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			body = newEnumResponse(name, e)
+		}
+
+		data, err := json.Marshal(body)
+		if err != nil {
+			writeError(w, api_error.NewInternalServerError("could not encode enums", err))
+			return
+		}
+		sum := sha256.Sum256(data)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		if etagMatches(req.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	})
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeError(w http.ResponseWriter, err api_error.ApiErr) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.StatusCode())
+	_ = json.NewEncoder(w).Encode(err)
+}
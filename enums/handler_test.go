@@ -0,0 +1,149 @@
+package enums
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func handlerRegistry() *Registry {
+	r := NewRegistry()
+	accountTypes := &Enum{Items: []EnumItem{{Idx: 0, Val: "Basic"}, {Idx: 1, Val: "Legacy"}}}
+	accountTypes.SetMetadata(0, ItemMetadata{Label: "Basic account"})
+	accountTypes.SetMetadata(1, ItemMetadata{Deprecated: true, ReplacedBy: new(int32(0))})
+	_ = r.Register("AccountTypes", accountTypes)
+	_ = r.Register("Countries", &Enum{Items: []EnumItem{{Idx: 0, Val: "DE"}}})
+	return r
+}
+
+func serve(h http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerServesAllEnums(t *testing.T) {
+	rec := serve(handlerRegistry().Handler(), http.MethodGet, "/", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	var body enumsResponse
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.EqualValues(t, 2, len(body.Enums))
+	assert.EqualValues(t, "AccountTypes", body.Enums[0].Name)
+	assert.EqualValues(t, "Countries", body.Enums[1].Name)
+}
+
+func TestHandlerServesEnumByName(t *testing.T) {
+	h := http.StripPrefix("/enums", handlerRegistry().Handler())
+
+	rec := serve(h, http.MethodGet, "/enums/AccountTypes", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"name": "AccountTypes",
+		"items": [
+			{"index": 0, "value": "Basic", "label": "Basic account"},
+			{"index": 1, "value": "Legacy", "deprecated": true, "replacedBy": 0}
+		],
+		"values": ["Basic", "Legacy"],
+		"map": {"0": "Basic", "1": "Legacy"}
+	}`, rec.Body.String())
+}
+
+func TestHandlerStringifiesAttributesThatCannotBeEncoded(t *testing.T) {
+	r := handlerRegistry()
+	e, _ := r.Lookup("Countries")
+	e.SetMetadata(0, ItemMetadata{Attributes: map[string]any{"ratio": math.NaN(), "order": 1}})
+
+	rec := serve(r.Handler(), http.MethodGet, "/", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"attributes":{"order":1,"ratio":"NaN"}`)
+	assert.True(t, math.IsNaN(e.Metadata(0).Attributes["ratio"].(float64)))
+}
+
+func TestHandlerUsesPathValue(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/enums/{name}", handlerRegistry().Handler())
+
+	rec := serve(mux, http.MethodGet, "/api/enums/Countries", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"Countries"`)
+}
+
+func TestHandlerUnknownEnumReturnsNotFound(t *testing.T) {
+	rec := serve(handlerRegistry().Handler(), http.MethodGet, "/Unknown", nil)
+
+	assert.EqualValues(t, http.StatusNotFound, rec.Code)
+	apiErr, err := api_error.NewErrorFromBytes(rec.Body.Bytes())
+	assert.Nil(t, err)
+	assert.EqualValues(t, "No enum with name Unknown found", apiErr.Message())
+}
+
+func TestHandlerIfNoneMatchReturnsNotModified(t *testing.T) {
+	h := handlerRegistry().Handler()
+	etag := serve(h, http.MethodGet, "/Countries", nil).Header().Get("ETag")
+
+	rec := serve(h, http.MethodGet, "/Countries", http.Header{"If-None-Match": {`"other", W/` + etag}})
+
+	assert.EqualValues(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.EqualValues(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestHandlerETagChangesWithEnum(t *testing.T) {
+	r := handlerRegistry()
+	h := r.Handler()
+	before := serve(h, http.MethodGet, "/Countries", nil).Header().Get("ETag")
+
+	e, _ := r.Lookup("Countries")
+	e.Items = append(e.Items, EnumItem{Idx: 1, Val: "AT"})
+	rec := serve(h, http.MethodGet, "/Countries", http.Header{"If-None-Match": {before}})
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.NotEqualValues(t, before, rec.Header().Get("ETag"))
+}
+
+func TestHandlerHeadReturnsNoBody(t *testing.T) {
+	rec := serve(handlerRegistry().Handler(), http.MethodHead, "/", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+}
+
+func TestHandlerRejectsOtherMethods(t *testing.T) {
+	rec := serve(handlerRegistry().Handler(), http.MethodPost, "/", nil)
+
+	assert.EqualValues(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.EqualValues(t, "GET, HEAD", rec.Header().Get("Allow"))
+}
+
+func TestDefinitionRoundTrip(t *testing.T) {
+	e := metadataEnum()
+
+	result, err := e.Definition("Colors").Enum()
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, e.Items, result.Items)
+}
+
+func TestPackageHandlerServesDefaultRegistry(t *testing.T) {
+	_ = Register("HandlerDefaultTest", &Enum{Items: []EnumItem{{Idx: 0, Val: "On"}}})
+
+	rec := serve(Handler(), http.MethodGet, "/HandlerDefaultTest", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+}
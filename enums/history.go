@@ -0,0 +1,139 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	var AccountTypes = Enum{[]EnumItem{{1, "Premium"}, {10, "Basic"}}}
+//
+//	AccountTypes.SetHistory([]Change{
+//		{Version: "2", Kind: ChangeRenamed, Index: 1, Value: "Advanced"},
+//		{Version: "3", Kind: ChangeMerged, Index: 2, Value: "Gold", Into: new(int32(1))},
+//		{Version: "3", Kind: ChangeRemoved, Index: 3, Value: "Trial"},
+//		{Version: "4", Kind: ChangeMoved, Index: 0, Value: "Basic", Into: new(int32(10))},
+//	})
+//	item, err := AccountTypes.ResolveLegacyValue("Gold") // Premium
+//
+// History records how items changed over time, so old records still resolve to the current item.
+
+type ChangeKind string
+
+const (
+	ChangeRenamed ChangeKind = "renamed"
+	ChangeMerged  ChangeKind = "merged"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeMoved   ChangeKind = "moved"
+)
+
+// Change describes what happened to an item in a version. Index and Value identify the item before the change.
+type Change struct {
+	Version string     `json:"version" yaml:"version"`
+	Kind    ChangeKind `json:"kind" yaml:"kind"`
+	Index   int32      `json:"index" yaml:"index"`
+	Value   string     `json:"value" yaml:"value"`
+	// Into is the index of the item that took over a merged item, or the new index of a moved item. Renamed items
+	// default to their own index.
+	Into *int32 `json:"into,omitempty" yaml:"into,omitempty"`
+}
+
+func (c Change) target() (int32, bool) {
+	switch {
+	case c.Kind == ChangeRemoved:
+		return 0, false
+	case c.Into != nil:
+		return *c.Into, true
+	default:
+		return c.Index, c.Kind == ChangeRenamed || c.Kind == ChangeMoved
+	}
+}
+
+// ResolveLegacyIndex returns the current item for an index that may have been merged or removed since.
+func (e *Enum) ResolveLegacyIndex(i int32) (*EnumItem, api_error.ApiErr) {
+	idx := i
+	for range len(e.History()) + 1 {
+		if item, err := e.ItemByIndex(idx); err == nil {
+			return item, nil
+		}
+		change, ok := e.lastChange(func(c Change) bool {
+			return c.Index == idx
+		})
+		if !ok {
+			break
+		}
+		next, ok := change.target()
+		if !ok {
+			return nil, api_error.NewNotFoundError(fmt.Sprintf("Item with index %v was removed in version %v", idx, change.Version))
+		}
+		idx = next
+	}
+	return nil, api_error.NewNotFoundError(fmt.Sprintf("No item with index %v found", i))
+}
+
+// ResolveLegacyValue returns the current item for a value that may have been renamed, merged or removed since.
+func (e *Enum) ResolveLegacyValue(v string) (*EnumItem, api_error.ApiErr) {
+	if item, err := e.ItemByValue(v); err == nil {
+		return item, nil
+	}
+	change, ok := e.lastChange(func(c Change) bool {
+		return strings.EqualFold(c.Value, v)
+	})
+	if !ok {
+		return nil, api_error.NewNotFoundError(fmt.Sprintf("No item with value %v found", v))
+	}
+	next, ok := change.target()
+	if !ok {
+		return nil, api_error.NewNotFoundError(fmt.Sprintf("Item with value %v was removed in version %v", v, change.Version))
+	}
+	return e.ResolveLegacyIndex(next)
+}
+
+func (e *Enum) lastChange(match func(Change) bool) (Change, bool) {
+	history := e.History()
+	for i := len(history) - 1; i >= 0; i-- {
+		if match(history[i]) {
+			return history[i], true
+		}
+	}
+	return Change{}, false
+}
+
+func (e *Enum) validateHistory() []any {
+	causes := make([]any, 0)
+	history := e.History()
+	known := make(map[int32]struct{}, len(e.Items)+len(history))
+	for _, item := range e.Items {
+		known[item.Idx] = struct{}{}
+	}
+	for _, c := range history {
+		known[c.Index] = struct{}{}
+	}
+	for i, c := range history {
+		switch c.Kind {
+		case ChangeRenamed, ChangeMerged, ChangeMoved:
+			if c.Kind != ChangeRenamed && c.Into == nil {
+				causes = append(causes, fmt.Sprintf("%v change %v has no target", c.Kind, i))
+				continue
+			}
+			if target, _ := c.target(); !isKnown(known, target) {
+				causes = append(causes, fmt.Sprintf("change %v has unknown target %v", i, target))
+			}
+		case ChangeRemoved:
+			if c.Into != nil {
+				causes = append(causes, fmt.Sprintf("removed change %v must not have a target", i))
+			}
+		default:
+			causes = append(causes, fmt.Sprintf("change %v has unknown kind %v", i, c.Kind))
+		}
+	}
+	return causes
+}
+
+func isKnown(known map[int32]struct{}, idx int32) bool {
+	_, ok := known[idx]
+	return ok
+}
@@ -0,0 +1,137 @@
+package enums
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func historyEnum() *Enum {
+	e := &Enum{
+		Items: []EnumItem{{Idx: 0, Val: "Basic"}, {Idx: 1, Val: "Premium"}},
+	}
+	e.SetHistory([]Change{
+		{Version: "2", Kind: ChangeRenamed, Index: 1, Value: "Advanced"},
+		{Version: "3", Kind: ChangeMerged, Index: 2, Value: "Gold", Into: new(int32(4))},
+		{Version: "3", Kind: ChangeRemoved, Index: 3, Value: "Trial"},
+		{Version: "4", Kind: ChangeMerged, Index: 4, Value: "Platinum", Into: new(int32(1))},
+	})
+	return e
+}
+
+func TestResolveLegacyIndexReturnsCurrentItem(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyIndex(1)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Premium", item.Val)
+}
+
+func TestResolveLegacyIndexFollowsMergeChain(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyIndex(2)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, item.Idx)
+}
+
+func TestResolveLegacyIndexRemovedReturnsNotFound(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyIndex(3)
+
+	assert.Nil(t, item)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+	assert.EqualValues(t, "Item with index 3 was removed in version 3", err.Message())
+}
+
+func TestResolveLegacyIndexUnknownReturnsNotFound(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyIndex(9)
+
+	assert.Nil(t, item)
+	assert.EqualValues(t, "No item with index 9 found", err.Message())
+}
+
+func TestResolveLegacyIndexStopsOnCycle(t *testing.T) {
+	e := &Enum{}
+	e.SetHistory([]Change{
+		{Kind: ChangeMerged, Index: 1, Into: new(int32(2))},
+		{Kind: ChangeMerged, Index: 2, Into: new(int32(1))},
+	})
+
+	item, err := e.ResolveLegacyIndex(1)
+
+	assert.Nil(t, item)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestResolveLegacyValueRenamed(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyValue("advanced")
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Premium", item.Val)
+}
+
+func TestResolveLegacyValueMerged(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyValue("Gold")
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Premium", item.Val)
+}
+
+func TestResolveLegacyValueRemovedReturnsNotFound(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyValue("Trial")
+
+	assert.Nil(t, item)
+	assert.EqualValues(t, "Item with value Trial was removed in version 3", err.Message())
+}
+
+func TestResolveLegacyValueCurrentWins(t *testing.T) {
+	item, err := historyEnum().ResolveLegacyValue("Basic")
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, item.Idx)
+}
+
+func TestResolveLegacyIndexFollowsMove(t *testing.T) {
+	e := &Enum{[]EnumItem{{10, "Basic"}}}
+	e.SetHistory([]Change{{Version: "2", Kind: ChangeMoved, Index: 0, Value: "Basic", Into: new(int32(10))}})
+
+	item, err := e.ResolveLegacyIndex(0)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Basic", item.Val)
+	assert.Nil(t, e.Validate())
+}
+
+func TestValidateRejectsInvalidHistory(t *testing.T) {
+	e := &Enum{
+		Items: []EnumItem{{Idx: 0, Val: "Basic"}},
+	}
+	e.SetHistory([]Change{
+		{Kind: ChangeMerged, Index: 1, Value: "Gold"},
+		{Kind: ChangeRemoved, Index: 2, Value: "Trial", Into: new(int32(0))},
+		{Kind: ChangeMerged, Index: 3, Value: "Silver", Into: new(int32(7))},
+		{Kind: "split", Index: 4, Value: "Bronze"},
+		{Kind: ChangeMoved, Index: 5, Value: "Iron"},
+	})
+
+	err := e.Validate()
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, []any{
+		"merged change 0 has no target",
+		"removed change 1 must not have a target",
+		"change 2 has unknown target 7",
+		"change 3 has unknown kind split",
+		"moved change 4 has no target",
+	}, err.Causes())
+}
+
+func TestLoadFileReadsHistory(t *testing.T) {
+	loaded, err := LoadFile("testdata/history.yaml")
+
+	assert.Nil(t, err)
+	item, err := loaded["OrderStatus"].ResolveLegacyValue("Paid")
+	assert.Nil(t, err)
+	assert.EqualValues(t, "New", item.Val)
+	assert.EqualValues(t, loaded["AccountTypes"].History(), loaded["AccountTypes"].Definition("AccountTypes").History)
+}
@@ -0,0 +1,139 @@
+package enums
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// enumLookup maps indexes and case folded values to positions in Items. The maps are only a shortcut: every hit
+// is checked against Items, and lookups that miss or hit a changed item search Items and rebuild the maps. Changes
+// to Items therefore never return a wrong item, whether Items was replaced, appended to or modified in place.
+type enumLookup struct {
+	byIndex map[int32]int
+	byValue map[string]int
+}
+
+func newEnumLookup(items []EnumItem, s *enumState) *enumLookup {
+	l := &enumLookup{
+		byIndex: make(map[int32]int, len(items)),
+		byValue: make(map[string]int, len(items)),
+	}
+	// Values take precedence over aliases, and the first match wins for duplicates, like the linear search did.
+	for i := len(items) - 1; i >= 0; i-- {
+		for _, alias := range s.metadataOf(items[i].Idx).Aliases {
+			l.byValue[foldKey(alias)] = i
+		}
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		l.byIndex[items[i].Idx] = i
+		l.byValue[foldKey(items[i].Val)] = i
+	}
+	return l
+}
+
+// linearLookupItems is the number of items up to which a linear search is faster than the lookup maps.
+const linearLookupItems = 16
+
+// findIndex returns the position of the first item with index i, or -1.
+func (e *Enum) findIndex(i int32) int {
+	if len(e.Items) <= linearLookupItems {
+		return slices.IndexFunc(e.Items, func(item EnumItem) bool {
+			return item.Idx == i
+		})
+	}
+	s := e.state()
+	if l := s.lookup.Load(); l != nil {
+		if pos, ok := l.byIndex[i]; ok && pos < len(e.Items) && e.Items[pos].Idx == i {
+			return pos
+		}
+	}
+	for pos := range e.Items {
+		if e.Items[pos].Idx == i {
+			s.lookup.Store(newEnumLookup(e.Items, s))
+			return pos
+		}
+	}
+	return -1
+}
+
+// findValue returns the position of the first item with value v, then of the first item with alias v, or -1.
+// Small enums only read the metadata for aliases if no value matches.
+func (e *Enum) findValue(v string) int {
+	if len(e.Items) <= linearLookupItems {
+		if pos := slices.IndexFunc(e.Items, func(item EnumItem) bool {
+			return strings.EqualFold(item.Val, v)
+		}); pos >= 0 {
+			return pos
+		}
+		s, ok := e.existingState()
+		if !ok {
+			return -1
+		}
+		return slices.IndexFunc(e.Items, func(item EnumItem) bool {
+			return s.matchesValue(item, v)
+		})
+	}
+	s := e.state()
+	if l := s.lookup.Load(); l != nil {
+		if pos, ok := l.byValue[foldKey(v)]; ok && pos < len(e.Items) && s.matchesValue(e.Items[pos], v) {
+			return pos
+		}
+	}
+	pos := slices.IndexFunc(e.Items, func(item EnumItem) bool {
+		return strings.EqualFold(item.Val, v)
+	})
+	if pos < 0 {
+		pos = slices.IndexFunc(e.Items, func(item EnumItem) bool {
+			return s.matchesValue(item, v)
+		})
+	}
+	if pos >= 0 {
+		s.lookup.Store(newEnumLookup(e.Items, s))
+	}
+	return pos
+}
+
+func (s *enumState) matchesValue(item EnumItem, v string) bool {
+	return strings.EqualFold(item.Val, v) || slices.ContainsFunc(s.metadataOf(item.Idx).Aliases, func(alias string) bool {
+		return strings.EqualFold(alias, v)
+	})
+}
+
+// Reindex rebuilds the lookup maps ahead of the next lookup, which would otherwise rebuild them on a miss.
+func (e *Enum) Reindex() {
+	s := e.state()
+	s.lookup.Store(newEnumLookup(e.Items, s))
+}
+
+// foldKey maps every rune to the smallest rune of its Unicode case folding orbit, so that two strings have the
+// same key exactly when strings.EqualFold reports them as equal.
+func foldKey(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		b := []byte(s)
+		for i, c := range b {
+			if 'a' <= c && c <= 'z' {
+				b[i] = c - 'a' + 'A'
+			}
+		}
+		return string(b)
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			folded = min(folded, f)
+		}
+		runes[i] = folded
+	}
+	return string(runes)
+}
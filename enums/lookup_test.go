@@ -0,0 +1,246 @@
+package enums
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"weak"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func largeEnum(n int) *Enum {
+	e := &Enum{
+		Items: make([]EnumItem, 0, n),
+	}
+	for i := range n {
+		e.Items = append(e.Items, EnumItem{
+			Idx: int32(i),
+			Val: fmt.Sprintf("Code%03d", i),
+		})
+	}
+	return e
+}
+
+// linearItemByValue is the previous implementation, kept as baseline for the benchmarks.
+func linearItemByValue(e *Enum, v string) *EnumItem {
+	for i := range e.Items {
+		if strings.EqualFold(v, e.Items[i].Val) {
+			return &e.Items[i]
+		}
+	}
+	return nil
+}
+
+func linearItemByIndex(e *Enum, idx int32) *EnumItem {
+	for i := range e.Items {
+		if e.Items[i].Idx == idx {
+			return &e.Items[i]
+		}
+	}
+	return nil
+}
+
+func TestFoldKeyMatchesEqualFold(t *testing.T) {
+	pairs := []struct {
+		a, b string
+	}{
+		{"basic", "BASIC"},
+		{"Straße", "STRAßE"},
+		{"kelvin", "Kelvin"},
+		{"ſtop", "STOP"},
+		{"Ωmega", "ωMEGA"},
+		{"émile", "ÉMILE"},
+	}
+
+	for _, p := range pairs {
+		assert.True(t, strings.EqualFold(p.a, p.b), p.a)
+		assert.EqualValues(t, foldKey(p.a), foldKey(p.b), p.a)
+	}
+	assert.NotEqualValues(t, foldKey("basic"), foldKey("basics"))
+}
+
+func TestItemByValueUnicodeCaseFolding(t *testing.T) {
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "Größe"}, {Idx: 1, Val: "Ωmega"}},
+	}
+
+	item, err := e.ItemByValue("GRÖSSE")
+	assert.Nil(t, item)
+	assert.NotNil(t, err)
+
+	item, err = e.ItemByValue("ωMEGA")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, item.Idx)
+}
+
+func TestLookupFollowsReplacedAndAppendedItems(t *testing.T) {
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "one"}},
+	}
+	_, err := e.ItemByValue("one")
+	assert.Nil(t, err)
+
+	e.Items = append(e.Items, EnumItem{Idx: 1, Val: "two"})
+	item, err := e.ItemByValue("two")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, item.Idx)
+
+	e.Items = []EnumItem{{Idx: 7, Val: "seven"}}
+	_, err = e.ItemByIndex(0)
+	assert.NotNil(t, err)
+	item, err = e.ItemByIndex(7)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "seven", item.Val)
+}
+
+func TestLookupFollowsAppendAfterTruncation(t *testing.T) {
+	e := Enum{
+		Items: append(make([]EnumItem, 0, 4), EnumItem{Idx: 0, Val: "A"}, EnumItem{Idx: 1, Val: "B"}),
+	}
+	_, err := e.ItemByValue("B")
+	assert.Nil(t, err)
+
+	e.Items = append(e.Items[:1], EnumItem{Idx: 2, Val: "C"})
+
+	item, err := e.ItemByValue("B")
+	assert.Nil(t, item)
+	assert.NotNil(t, err)
+	item, err = e.ItemByValue("C")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, item.Idx)
+	item, err = e.ItemByIndex(2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "C", item.Val)
+	_, err = e.ItemByIndex(1)
+	assert.NotNil(t, err)
+}
+
+func TestLookupFollowsInPlaceChanges(t *testing.T) {
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "old"}, {Idx: 1, Val: "other"}},
+	}
+	_, _ = e.ItemByValue("old")
+
+	e.Items[0] = EnumItem{Idx: 5, Val: "new"}
+
+	_, err := e.ItemByValue("old")
+	assert.NotNil(t, err)
+	_, err = e.ItemByIndex(0)
+	assert.NotNil(t, err)
+	item, err := e.ItemByValue("new")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5, item.Idx)
+}
+
+func TestStateIsRemovedWithEnum(t *testing.T) {
+	key := func() weak.Pointer[Enum] {
+		e := &Enum{Items: []EnumItem{{Idx: 0, Val: "a"}}}
+		_, _ = e.ItemByValue("a")
+		return weak.Make(e)
+	}()
+
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		_, ok := states.Load(key)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReindexPicksUpInPlaceChanges(t *testing.T) {
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "old"}},
+	}
+	_, _ = e.ItemByValue("old")
+
+	e.Items[0].Val = "new"
+	e.Reindex()
+
+	item, err := e.ItemByValue("new")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, item.Idx)
+	_, err = e.ItemByValue("old")
+	assert.NotNil(t, err)
+}
+
+func TestLookupDuplicatesReturnFirstItem(t *testing.T) {
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "a"}, {Idx: 0, Val: "A"}},
+	}
+
+	byIndex, _ := e.ItemByIndex(0)
+	byValue, _ := e.ItemByValue("a")
+
+	assert.Same(t, &e.Items[0], byIndex)
+	assert.Same(t, &e.Items[0], byValue)
+}
+
+func TestLookupConcurrentReads(t *testing.T) {
+	e := largeEnum(300)
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := e.ItemByValue(fmt.Sprintf("code%03d", i))
+			assert.Nil(t, err)
+			assert.EqualValues(t, i, item.Idx)
+			_, err = e.ItemByIndex(int32(i))
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkItemByValue(b *testing.B) {
+	e := largeEnum(300)
+	for b.Loop() {
+		_, _ = e.ItemByValue("code299")
+	}
+}
+
+func BenchmarkItemByValueLinear(b *testing.B) {
+	e := largeEnum(300)
+	for b.Loop() {
+		_ = linearItemByValue(e, "code299")
+	}
+}
+
+func BenchmarkItemByIndex(b *testing.B) {
+	e := largeEnum(300)
+	for b.Loop() {
+		_, _ = e.ItemByIndex(299)
+	}
+}
+
+func BenchmarkItemByIndexLinear(b *testing.B) {
+	e := largeEnum(300)
+	for b.Loop() {
+		_ = linearItemByIndex(e, 299)
+	}
+}
+
+func BenchmarkItemByValueSmall(b *testing.B) {
+	e := &Enum{Items: []EnumItem{{Idx: 0, Val: "Basic"}, {Idx: 1, Val: "Advanced"}}}
+	for b.Loop() {
+		_, _ = e.ItemByValue("advanced")
+	}
+}
+
+func BenchmarkItemByIndexSmall(b *testing.B) {
+	e := &Enum{Items: []EnumItem{{Idx: 0, Val: "Basic"}, {Idx: 1, Val: "Advanced"}}}
+	for b.Loop() {
+		_, _ = e.ItemByIndex(1)
+	}
+}
+
+func BenchmarkItemByValueAtThreshold(b *testing.B) {
+	e := largeEnum(linearLookupItems)
+	v := fmt.Sprintf("code%03d", linearLookupItems-1)
+	for b.Loop() {
+		_, _ = e.ItemByValue(v)
+	}
+}
@@ -0,0 +1,145 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// ItemMetadata describes an item beyond its index and value. It is set per index with SetMetadata.
+type ItemMetadata struct {
+	// Label is the display name, Labels holds its translations by language, e.g. "de" or "de-AT".
+	Label       string
+	Labels      map[string]string
+	Description string
+	// Deprecated items are left out of ActiveValues but still resolve for old data.
+	Deprecated bool
+	// ReplacedBy is the index of the item replacing a deprecated item.
+	ReplacedBy *int32
+	// Aliases are alternate spellings accepted by ItemByValue.
+	Aliases []string
+	// Attributes holds arbitrary metadata such as colors or sort order.
+	Attributes map[string]any
+}
+
+// isZero reports whether no metadata is set.
+func (m ItemMetadata) isZero() bool {
+	return m.Label == "" && len(m.Labels) == 0 && m.Description == "" && !m.Deprecated && m.ReplacedBy == nil &&
+		len(m.Aliases) == 0 && len(m.Attributes) == 0
+}
+
+// DisplayLabel returns the label of the item with index i for the language, then its Label, then its value.
+// Languages are compared case-insensitively with "_" read as "-", and "de-DE" falls back to "de".
+func (e *Enum) DisplayLabel(i int32, lang string) string {
+	item, err := e.ItemByIndex(i)
+	if err != nil {
+		return ""
+	}
+	m := e.Metadata(i)
+	lang = normalizeLanguage(lang)
+	base, _, _ := strings.Cut(lang, "-")
+	fallback, found := "", false
+	for key, label := range m.Labels {
+		switch normalizeLanguage(key) {
+		case lang:
+			return label
+		case base:
+			fallback, found = label, true
+		}
+	}
+	if found {
+		return fallback
+	}
+	if m.Label != "" {
+		return m.Label
+	}
+	return item.Val
+}
+
+func normalizeLanguage(lang string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(lang)), "_", "-")
+}
+
+// ActiveItems returns the items that are not deprecated.
+func (e *Enum) ActiveItems() []EnumItem {
+	s := e.state()
+	items := make([]EnumItem, 0, len(e.Items))
+	for _, item := range e.Items {
+		if !s.metadataOf(item.Idx).Deprecated {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ActiveValues returns the values of the items that are not deprecated, e.g. for selection lists.
+func (e *Enum) ActiveValues() []string {
+	names := make([]string, 0, len(e.Items))
+	for _, item := range e.ActiveItems() {
+		names = append(names, item.Val)
+	}
+	return names
+}
+
+// Replacement follows ReplacedBy from the item with index i to the first item that is not deprecated.
+// Items that are not deprecated are returned as is.
+func (e *Enum) Replacement(i int32) (*EnumItem, api_error.ApiErr) {
+	item, err := e.ItemByIndex(i)
+	if err != nil {
+		return nil, err
+	}
+	for range len(e.Items) {
+		m := e.Metadata(item.Idx)
+		if !m.Deprecated {
+			return item, nil
+		}
+		if m.ReplacedBy == nil {
+			return nil, api_error.NewNotFoundError(fmt.Sprintf("Item with index %v is deprecated without replacement", item.Idx))
+		}
+		if item, err = e.ItemByIndex(*m.ReplacedBy); err != nil {
+			return nil, err
+		}
+	}
+	return nil, api_error.NewProcessingConflictError(fmt.Sprintf("Replacements of item with index %v form a cycle", i))
+}
+
+// validateMetadata checks aliases and replacements; values and indexes are checked by Validate.
+func (e *Enum) validateMetadata() []any {
+	s := e.state()
+	causes := make([]any, 0)
+	indexes := make(map[int32]struct{}, len(e.Items))
+	names := make(map[string]string, len(e.Items))
+	for _, item := range e.Items {
+		indexes[item.Idx] = struct{}{}
+		names[foldKey(item.Val)] = item.Val
+	}
+	for _, idx := range s.metadataIndexes() {
+		if _, ok := indexes[idx]; !ok {
+			causes = append(causes, fmt.Sprintf("metadata for unknown index %v", idx))
+		}
+	}
+	for _, item := range e.Items {
+		m := s.metadataOf(item.Idx)
+		for _, alias := range m.Aliases {
+			key := foldKey(alias)
+			if strings.TrimSpace(alias) == "" {
+				causes = append(causes, fmt.Sprintf("empty alias at index %v", item.Idx))
+				continue
+			}
+			if existing, ok := names[key]; ok && !strings.EqualFold(existing, item.Val) {
+				causes = append(causes, fmt.Sprintf("alias %v at index %v conflicts with %v", alias, item.Idx, existing))
+			}
+			names[key] = item.Val
+		}
+		if m.ReplacedBy != nil {
+			if !m.Deprecated {
+				causes = append(causes, fmt.Sprintf("item at index %v has a replacement but is not deprecated", item.Idx))
+			}
+			if _, ok := indexes[*m.ReplacedBy]; !ok || *m.ReplacedBy == item.Idx {
+				causes = append(causes, fmt.Sprintf("invalid replacement %v for index %v", *m.ReplacedBy, item.Idx))
+			}
+		}
+	}
+	return causes
+}
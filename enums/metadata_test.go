@@ -0,0 +1,157 @@
+package enums
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func metadataEnum() *Enum {
+	e := &Enum{[]EnumItem{{0, "Red"}, {1, "Green"}, {2, "Olive"}, {3, "Khaki"}, {4, "Mauve"}}}
+	e.SetMetadata(0, ItemMetadata{
+		Label:  "Red color",
+		Labels: map[string]string{"de": "Rot", "pt-BR": "Vermelho"},
+		Aliases: []string{
+			"crimson",
+		},
+		Attributes: map[string]any{"color": "#ff0000", "order": 2},
+	})
+	e.SetMetadata(1, ItemMetadata{Description: "The color of grass"})
+	e.SetMetadata(2, ItemMetadata{Deprecated: true, ReplacedBy: new(int32(1))})
+	e.SetMetadata(3, ItemMetadata{Deprecated: true, ReplacedBy: new(int32(2))})
+	e.SetMetadata(4, ItemMetadata{Deprecated: true})
+	return e
+}
+
+func TestDisplayLabel(t *testing.T) {
+	e := metadataEnum()
+
+	assert.EqualValues(t, "Rot", e.DisplayLabel(0, "de"))
+	assert.EqualValues(t, "Rot", e.DisplayLabel(0, "DE-at"))
+	assert.EqualValues(t, "Rot", e.DisplayLabel(0, "de_AT"))
+	assert.EqualValues(t, "Vermelho", e.DisplayLabel(0, "pt-br"))
+	assert.EqualValues(t, "Vermelho", e.DisplayLabel(0, "pt_BR"))
+	assert.EqualValues(t, "Red color", e.DisplayLabel(0, "pt"))
+	assert.EqualValues(t, "Red color", e.DisplayLabel(0, "fr"))
+	assert.EqualValues(t, "Green", e.DisplayLabel(1, "de"))
+	assert.EqualValues(t, "", e.DisplayLabel(9, "de"))
+}
+
+func TestMetadataIsKeptOutsideItems(t *testing.T) {
+	e := metadataEnum()
+
+	assert.EqualValues(t, EnumItem{0, "Red"}, e.Items[0])
+	assert.EqualValues(t, "Red color", e.Metadata(0).Label)
+	assert.EqualValues(t, ItemMetadata{}, e.Metadata(9))
+}
+
+func TestItemByValueFollowsChangedAliases(t *testing.T) {
+	e := metadataEnum()
+	_, err := e.ItemByValue("crimson")
+	assert.Nil(t, err)
+
+	e.SetMetadata(0, ItemMetadata{Aliases: []string{"scarlet"}})
+
+	_, err = e.ItemByValue("crimson")
+	assert.NotNil(t, err)
+	item, err := e.ItemByValue("SCARLET")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, item.Idx)
+}
+
+func TestItemByValueResolvesAliases(t *testing.T) {
+	e := metadataEnum()
+
+	item, err := e.ItemByValue("CRIMSON")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, item.Idx)
+
+	idx, err := e.AsIndex("crimson")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, idx)
+}
+
+func TestItemByValuePrefersValuesOverAliases(t *testing.T) {
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "a"}, {Idx: 1, Val: "b"}},
+	}
+	e.SetMetadata(0, ItemMetadata{Aliases: []string{"b"}})
+
+	item, err := e.ItemByValue("b")
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, item.Idx)
+}
+
+func TestActiveValuesLeavesOutDeprecated(t *testing.T) {
+	e := metadataEnum()
+
+	assert.EqualValues(t, []string{"Red", "Green"}, e.ActiveValues())
+	assert.EqualValues(t, 2, len(e.ActiveItems()))
+	assert.EqualValues(t, 5, len(e.Values()))
+}
+
+func TestAsValueResolvesDeprecated(t *testing.T) {
+	e := metadataEnum()
+
+	val, err := e.AsValue(2)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Olive", val)
+}
+
+func TestReplacementFollowsChain(t *testing.T) {
+	e := metadataEnum()
+
+	item, err := e.Replacement(3)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Green", item.Val)
+
+	item, err = e.Replacement(0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Red", item.Val)
+}
+
+func TestReplacementWithoutReplacementReturnsNotFound(t *testing.T) {
+	item, err := metadataEnum().Replacement(4)
+	assert.Nil(t, item)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestReplacementCycleReturnsConflict(t *testing.T) {
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "a"}, {Idx: 1, Val: "b"}},
+	}
+	e.SetMetadata(0, ItemMetadata{Deprecated: true, ReplacedBy: new(int32(1))})
+	e.SetMetadata(1, ItemMetadata{Deprecated: true, ReplacedBy: new(int32(0))})
+
+	item, err := e.Replacement(0)
+
+	assert.Nil(t, item)
+	assert.EqualValues(t, http.StatusConflict, err.StatusCode())
+}
+
+func TestValidateMetadata(t *testing.T) {
+	assert.Nil(t, metadataEnum().Validate())
+
+	e := Enum{
+		Items: []EnumItem{{Idx: 0, Val: "a"}, {Idx: 1, Val: "b"}, {Idx: 2, Val: "c"}},
+	}
+	e.SetMetadata(0, ItemMetadata{Aliases: []string{"B", " "}})
+	e.SetMetadata(1, ItemMetadata{ReplacedBy: new(int32(1))})
+	e.SetMetadata(2, ItemMetadata{Deprecated: true, ReplacedBy: new(int32(9))})
+	e.SetMetadata(5, ItemMetadata{Label: "unknown"})
+
+	err := e.Validate()
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, []any{
+		"metadata for unknown index 5",
+		"alias B at index 0 conflicts with b",
+		"empty alias at index 0",
+		"item at index 1 has a replacement but is not deprecated",
+		"invalid replacement 1 for index 1",
+		"invalid replacement 9 for index 2",
+	}, err.Causes())
+}
@@ -0,0 +1,88 @@
+package enums
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Registry holds named enums, e.g. for struct tag validation. It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	enums map[string]*Enum
+}
+
+var defaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		enums: make(map[string]*Enum),
+	}
+}
+
+// DefaultRegistry returns the registry used by the package level functions.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+func (r *Registry) Register(name string, e *Enum) api_error.ApiErr {
+	name = strings.TrimSpace(name)
+	if name == "" || e == nil {
+		return api_error.NewBadRequestError("enum name and enum must not be empty")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.enums[name]; ok {
+		return api_error.NewProcessingConflictError(fmt.Sprintf("Enum %v is already registered", name))
+	}
+	r.enums[name] = e
+	return nil
+}
+
+// RegisterAll registers enums by name, e.g. the result of LoadFile, in ascending name order.
+func (r *Registry) RegisterAll(enums map[string]*Enum) api_error.ApiErr {
+	names := make([]string, 0, len(enums))
+	for name := range enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := r.Register(name, enums[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) Lookup(name string) (*Enum, api_error.ApiErr) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.enums[name]
+	if !ok {
+		return nil, api_error.NewNotFoundError(fmt.Sprintf("No enum with name %v found", name))
+	}
+	return e, nil
+}
+
+// Names returns the registered names in ascending order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.enums))
+	for name := range r.enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Register(name string, e *Enum) api_error.ApiErr {
+	return defaultRegistry.Register(name, e)
+}
+
+func Lookup(name string) (*Enum, api_error.ApiErr) {
+	return defaultRegistry.Lookup(name)
+}
@@ -0,0 +1,72 @@
+package enums
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryRegisterAndLookup(t *testing.T) {
+	r := NewRegistry()
+	e := &Enum{Items: []EnumItem{eItemOne}}
+
+	assert.Nil(t, r.Register(" Tests ", e))
+
+	found, err := r.Lookup("Tests")
+	assert.Nil(t, err)
+	assert.Same(t, e, found)
+}
+
+func TestRegistryRegisterDuplicateReturnsConflict(t *testing.T) {
+	r := NewRegistry()
+	assert.Nil(t, r.Register("Tests", &Enum{}))
+
+	err := r.Register("Tests", &Enum{})
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusConflict, err.StatusCode())
+}
+
+func TestRegistryRegisterInvalidReturnsBadRequest(t *testing.T) {
+	r := NewRegistry()
+	assert.EqualValues(t, http.StatusBadRequest, r.Register("", &Enum{}).StatusCode())
+	assert.EqualValues(t, http.StatusBadRequest, r.Register("Tests", nil).StatusCode())
+}
+
+func TestRegistryLookupUnknownReturnsNotFound(t *testing.T) {
+	e, err := NewRegistry().Lookup("Unknown")
+	assert.Nil(t, e)
+	assert.EqualValues(t, "No enum with name Unknown found", err.Message())
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestRegistryNamesAreSorted(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register("b", &Enum{})
+	_ = r.Register("a", &Enum{})
+
+	assert.EqualValues(t, []string{"a", "b"}, r.Names())
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	e := &Enum{}
+	assert.Nil(t, Register("DefaultRegistryTest", e))
+
+	found, err := Lookup("DefaultRegistryTest")
+
+	assert.Nil(t, err)
+	assert.Same(t, e, found)
+	assert.Contains(t, DefaultRegistry().Names(), "DefaultRegistryTest")
+}
+
+func TestRegistryRegisterAll(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register("b", &Enum{})
+
+	assert.Nil(t, r.RegisterAll(map[string]*Enum{"a": {}, "c": {}}))
+	err := r.RegisterAll(map[string]*Enum{"b": {}, "d": {}})
+
+	assert.EqualValues(t, http.StatusConflict, err.StatusCode())
+	assert.EqualValues(t, []string{"a", "b", "c"}, r.Names())
+}
@@ -0,0 +1,87 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Schema is a JSON Schema / OpenAPI 3.1 fragment describing an enum.
+type Schema struct {
+	Type              string   `json:"type" yaml:"type"`
+	Format            string   `json:"format,omitempty" yaml:"format,omitempty"`
+	Description       string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Enum              []any    `json:"enum" yaml:"enum"`
+	XEnumVarnames     []string `json:"x-enum-varnames,omitempty" yaml:"x-enum-varnames,omitempty"`
+	XEnumDescriptions []string `json:"x-enum-descriptions,omitempty" yaml:"x-enum-descriptions,omitempty"`
+}
+
+type ComponentSchemas struct {
+	Schemas map[string]Schema `json:"schemas" yaml:"schemas"`
+}
+
+// Components is an OpenAPI document fragment that can be referenced as "#/components/schemas/<name>".
+type Components struct {
+	Components ComponentSchemas `json:"components" yaml:"components"`
+}
+
+// Schema describes the enum as it is serialized: FormatName lists the values as strings,
+// FormatIndex lists the indexes as integers. x-enum-varnames holds the Go constant suffixes
+// generated by enumgen and is left out if enumgen rejects the values. x-enum-descriptions holds
+// the item descriptions or labels. As not all tools read x-enum-descriptions, the descriptions
+// are also listed in the schema description.
+func (e *Enum) Schema(format Format) Schema {
+	s := Schema{
+		Type: "string",
+		Enum: make([]any, 0, len(e.Items)),
+	}
+	if suffixes, err := constSuffixes(e.Items); err == nil {
+		s.XEnumVarnames = suffixes
+	}
+	if format == FormatIndex {
+		s.Type = "integer"
+		s.Format = "int32"
+	}
+
+	descriptions := make([]string, 0, len(e.Items))
+	lines := make([]string, 0, len(e.Items))
+	for _, item := range e.Items {
+		if format == FormatIndex {
+			s.Enum = append(s.Enum, item.Idx)
+		} else {
+			s.Enum = append(s.Enum, item.Val)
+		}
+
+		m := e.Metadata(item.Idx)
+		description := m.Description
+		if description == "" {
+			description = m.Label
+		}
+		if m.Deprecated {
+			description = strings.TrimSpace("Deprecated. " + description)
+		}
+		descriptions = append(descriptions, description)
+		if description != "" {
+			lines = append(lines, fmt.Sprintf("* `%v` - %v", s.Enum[len(s.Enum)-1], description))
+		}
+	}
+	if len(lines) > 0 {
+		s.XEnumDescriptions = descriptions
+		s.Description = strings.Join(lines, "\n")
+	}
+	return s
+}
+
+// Components returns the schemas of all registered enums, keyed by their registered names.
+func (r *Registry) Components(format Format) Components {
+	c := Components{
+		Components: ComponentSchemas{
+			Schemas: make(map[string]Schema),
+		},
+	}
+	for _, name := range r.Names() {
+		if e, err := r.Lookup(name); err == nil {
+			c.Components.Schemas[name] = e.Schema(format)
+		}
+	}
+	return c
+}
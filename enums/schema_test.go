@@ -0,0 +1,64 @@
+package enums
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaByName(t *testing.T) {
+	e := Enum{Items: []EnumItem{{Idx: 0, Val: "basic"}, {Idx: 1, Val: "two-factor"}}}
+
+	data, err := json.Marshal(e.Schema(FormatName))
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": "string",
+		"enum": ["basic", "two-factor"],
+		"x-enum-varnames": ["Basic", "TwoFactor"]
+	}`, string(data))
+}
+
+func TestSchemaOmitsVarnamesWithoutConstantNames(t *testing.T) {
+	e := Enum{Items: []EnumItem{{Idx: 0, Val: "plus"}, {Idx: 1, Val: "+"}}}
+
+	data, err := json.Marshal(e.Schema(FormatName))
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": "string",
+		"enum": ["plus", "+"]
+	}`, string(data))
+}
+
+func TestSchemaByIndexWithDescriptions(t *testing.T) {
+	e := Enum{Items: []EnumItem{{Idx: 0, Val: "Basic"}, {Idx: 1, Val: "Advanced"}, {Idx: 2, Val: "Legacy"}}}
+	e.SetMetadata(0, ItemMetadata{Label: "Basic account"})
+	e.SetMetadata(2, ItemMetadata{Deprecated: true})
+
+	data, err := json.Marshal(e.Schema(FormatIndex))
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": "integer",
+		"format": "int32",
+		"description": "* `+"`0` - Basic account\\n* `2`"+` - Deprecated.",
+		"enum": [0, 1, 2],
+		"x-enum-varnames": ["Basic", "Advanced", "Legacy"],
+		"x-enum-descriptions": ["Basic account", "", "Deprecated."]
+	}`, string(data))
+}
+
+func TestRegistryComponents(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register("Countries", &Enum{Items: []EnumItem{{Idx: 0, Val: "DE"}}})
+	_ = r.Register("AccountTypes", &Enum{Items: []EnumItem{{Idx: 0, Val: "Basic"}}})
+
+	c := r.Components(FormatName)
+
+	assert.EqualValues(t, 2, len(c.Components.Schemas))
+	assert.EqualValues(t, []any{"DE"}, c.Components.Schemas["Countries"].Enum)
+	data, _ := json.Marshal(c)
+	assert.Contains(t, string(data), `{"components":{"schemas":{"AccountTypes":{"type":"string"`)
+}
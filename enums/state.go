@@ -0,0 +1,92 @@
+package enums
+
+import (
+	"maps"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"weak"
+)
+
+// enumState holds what an Enum keeps besides its Items: the lookup maps, the item metadata and the history. It
+// lives outside the Enum, so that Enum and EnumItem keep their fields and unkeyed literals such as
+// Enum{[]EnumItem{{0, "Basic"}}} compile. The state belongs to the *Enum, a copy of an Enum value starts without it.
+type enumState struct {
+	lookup   atomic.Pointer[enumLookup]
+	mu       sync.RWMutex
+	history  []Change
+	metadata map[int32]ItemMetadata
+}
+
+// states maps weak pointers to enums to their state. An entry is removed once its enum is garbage collected.
+var states sync.Map // weak.Pointer[Enum] -> *enumState
+
+// state returns the state of e, creating it on first use.
+func (e *Enum) state() *enumState {
+	key := weak.Make(e)
+	if s, ok := states.Load(key); ok {
+		return s.(*enumState)
+	}
+	s, loaded := states.LoadOrStore(key, &enumState{})
+	if !loaded {
+		runtime.AddCleanup(e, func(key weak.Pointer[Enum]) {
+			states.Delete(key)
+		}, key)
+	}
+	return s.(*enumState)
+}
+
+// existingState returns the state of e without creating it.
+func (e *Enum) existingState() (*enumState, bool) {
+	s, ok := states.Load(weak.Make(e))
+	if !ok {
+		return nil, false
+	}
+	return s.(*enumState), true
+}
+
+// History returns the renamed, merged and removed items, oldest first.
+func (e *Enum) History() []Change {
+	s := e.state()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.history
+}
+
+// SetHistory replaces the history of the enum. Validate checks it against the items.
+func (e *Enum) SetHistory(history []Change) {
+	s := e.state()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = history
+}
+
+// Metadata returns the metadata of the item with index i, which is empty if none was set.
+func (e *Enum) Metadata(i int32) ItemMetadata {
+	return e.state().metadataOf(i)
+}
+
+// SetMetadata replaces the metadata of the item with index i. Validate checks aliases and replacements.
+func (e *Enum) SetMetadata(i int32, m ItemMetadata) {
+	s := e.state()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.metadata == nil {
+		s.metadata = make(map[int32]ItemMetadata)
+	}
+	s.metadata[i] = m
+}
+
+func (s *enumState) metadataOf(i int32) ItemMetadata {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.metadata[i]
+}
+
+// metadataIndexes returns the indexes that have metadata, sorted.
+func (s *enumState) metadataIndexes() []int32 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Sorted(maps.Keys(s.metadata))
+}
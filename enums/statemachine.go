@@ -0,0 +1,206 @@
+package enums
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	var OrderFlow = enums.NewStateMachine(&OrderStatus)
+//	OrderFlow.SetInitial(0)
+//	OrderFlow.Allow(0, 1, 3) // New -> Paid, New -> Cancelled
+//	OrderFlow.Allow(1, 2)    // Paid -> Shipped
+//	if err := OrderFlow.Transition(order.Status, newStatus); err != nil { ... }
+//
+// States are item indexes. Declare all transitions during initialization; afterwards the state machine is
+// safe for concurrent reads.
+
+type StateMachine struct {
+	Enum        *Enum
+	transitions map[int32][]int32
+	initial     map[int32]struct{}
+}
+
+func NewStateMachine(e *Enum) *StateMachine {
+	return &StateMachine{
+		Enum:        e,
+		transitions: make(map[int32][]int32),
+		initial:     make(map[int32]struct{}),
+	}
+}
+
+// Allow declares the transitions from one state to each of the given states.
+func (m *StateMachine) Allow(from int32, to ...int32) api_error.ApiErr {
+	for _, idx := range append([]int32{from}, to...) {
+		if _, err := m.Enum.ItemByIndex(idx); err != nil {
+			return err
+		}
+	}
+	for _, idx := range to {
+		if !slices.Contains(m.transitions[from], idx) {
+			m.transitions[from] = append(m.transitions[from], idx)
+		}
+	}
+	return nil
+}
+
+// SetInitial marks the states new entities may start in.
+func (m *StateMachine) SetInitial(idx ...int32) api_error.ApiErr {
+	for _, i := range idx {
+		if _, err := m.Enum.ItemByIndex(i); err != nil {
+			return err
+		}
+	}
+	for _, i := range idx {
+		m.initial[i] = struct{}{}
+	}
+	return nil
+}
+
+func (m *StateMachine) IsInitial(idx int32) bool {
+	_, ok := m.initial[idx]
+	return ok
+}
+
+// IsTerminal reports whether idx is a state without outgoing transitions.
+func (m *StateMachine) IsTerminal(idx int32) bool {
+	if _, err := m.Enum.ItemByIndex(idx); err != nil {
+		return false
+	}
+	return len(m.transitions[idx]) == 0
+}
+
+// InitialStates returns the initial states in item order.
+func (m *StateMachine) InitialStates() []int32 {
+	return m.states(m.IsInitial)
+}
+
+// TerminalStates returns the terminal states in item order.
+func (m *StateMachine) TerminalStates() []int32 {
+	return m.states(m.IsTerminal)
+}
+
+func (m *StateMachine) states(filter func(int32) bool) []int32 {
+	states := make([]int32, 0)
+	for _, item := range m.Enum.Items {
+		if filter(item.Idx) {
+			states = append(states, item.Idx)
+		}
+	}
+	return states
+}
+
+// Next returns the states that may follow from, in the order they were allowed.
+func (m *StateMachine) Next(from int32) []int32 {
+	return append([]int32{}, m.transitions[from]...)
+}
+
+func (m *StateMachine) CanTransition(from, to int32) bool {
+	return slices.Contains(m.transitions[from], to)
+}
+
+// Transition checks a state change. Unknown states return a NotFound error, disallowed moves a Conflict error.
+func (m *StateMachine) Transition(from, to int32) api_error.ApiErr {
+	fromVal, err := m.Enum.AsValue(from)
+	if err != nil {
+		return err
+	}
+	toVal, err := m.Enum.AsValue(to)
+	if err != nil {
+		return err
+	}
+	if !m.CanTransition(from, to) {
+		return api_error.NewProcessingConflictError(fmt.Sprintf("Transition from %v to %v is not allowed", fromVal, toVal))
+	}
+	return nil
+}
+
+// DOT renders the transitions as a Graphviz digraph. Initial states get an arrow from a start point,
+// terminal states are drawn as double circles. Nodes are named after the item indexes and labeled with the values,
+// so that no value collides with the start point.
+func (m *StateMachine) DOT(name string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %v {\n", strconv.Quote(name))
+	sb.WriteString("\tstart [shape=point];\n")
+	for _, item := range m.Enum.Items {
+		shape := "circle"
+		if m.IsTerminal(item.Idx) {
+			shape = "doublecircle"
+		}
+		fmt.Fprintf(&sb, "\t%v [label=%v, shape=%v];\n", dotID(item), strconv.Quote(item.Val), shape)
+	}
+	for _, item := range m.Enum.Items {
+		if m.IsInitial(item.Idx) {
+			fmt.Fprintf(&sb, "\tstart -> %v;\n", dotID(item))
+		}
+	}
+	m.eachTransition(func(from, to EnumItem) {
+		fmt.Fprintf(&sb, "\t%v -> %v;\n", dotID(from), dotID(to))
+	})
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid renders the transitions as a Mermaid state diagram.
+func (m *StateMachine) Mermaid() string {
+	ids := mermaidIDs(m.Enum.Items)
+	var sb strings.Builder
+	sb.WriteString("stateDiagram-v2\n")
+	for _, item := range m.Enum.Items {
+		if id := ids[item.Idx]; id != item.Val {
+			fmt.Fprintf(&sb, "    state %v as %v\n", strconv.Quote(item.Val), id)
+		}
+	}
+	for _, item := range m.Enum.Items {
+		if m.IsInitial(item.Idx) {
+			fmt.Fprintf(&sb, "    [*] --> %v\n", ids[item.Idx])
+		}
+	}
+	m.eachTransition(func(from, to EnumItem) {
+		fmt.Fprintf(&sb, "    %v --> %v\n", ids[from.Idx], ids[to.Idx])
+	})
+	for _, item := range m.Enum.Items {
+		if m.IsTerminal(item.Idx) {
+			fmt.Fprintf(&sb, "    %v --> [*]\n", ids[item.Idx])
+		}
+	}
+	return sb.String()
+}
+
+func (m *StateMachine) eachTransition(f func(from, to EnumItem)) {
+	for _, item := range m.Enum.Items {
+		for _, idx := range m.transitions[item.Idx] {
+			if to, err := m.Enum.ItemByIndex(idx); err == nil {
+				f(item, *to)
+			}
+		}
+	}
+}
+
+func dotID(item EnumItem) string {
+	return fmt.Sprintf("s%d", item.Idx)
+}
+
+// mermaidIDs returns an identifier per item index, as Mermaid state ids may not contain spaces or punctuation.
+// Values without letters or digits and values that share an identifier, such as "In Progress" and "in-progress",
+// get state<index>, which identifier never returns as it starts with an upper case letter.
+func mermaidIDs(items []EnumItem) map[int32]string {
+	counts := make(map[string]int, len(items))
+	for _, item := range items {
+		counts[identifier(item.Val)]++
+	}
+	ids := make(map[int32]string, len(items))
+	for _, item := range items {
+		id := identifier(item.Val)
+		if id == "" || counts[id] > 1 {
+			id = fmt.Sprintf("state%d", item.Idx)
+		}
+		ids[item.Idx] = id
+	}
+	return ids
+}
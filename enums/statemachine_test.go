@@ -0,0 +1,137 @@
+package enums
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	orderNew int32 = iota
+	orderPaid
+	orderShipped
+	orderCancelled
+)
+
+func orderFlow() *StateMachine {
+	m := NewStateMachine(&Enum{Items: []EnumItem{
+		{Idx: orderNew, Val: "New"},
+		{Idx: orderPaid, Val: "Paid"},
+		{Idx: orderShipped, Val: "Shipped"},
+		{Idx: orderCancelled, Val: "in review"},
+	}})
+	_ = m.SetInitial(orderNew)
+	_ = m.Allow(orderNew, orderPaid, orderCancelled)
+	_ = m.Allow(orderPaid, orderShipped, orderCancelled)
+	return m
+}
+
+func TestStateMachineCanTransition(t *testing.T) {
+	m := orderFlow()
+
+	assert.True(t, m.CanTransition(orderNew, orderPaid))
+	assert.True(t, m.CanTransition(orderPaid, orderCancelled))
+	assert.False(t, m.CanTransition(orderNew, orderShipped))
+	assert.False(t, m.CanTransition(orderShipped, orderNew))
+	assert.EqualValues(t, []int32{orderPaid, orderCancelled}, m.Next(orderNew))
+}
+
+func TestStateMachineTransition(t *testing.T) {
+	m := orderFlow()
+
+	assert.Nil(t, m.Transition(orderNew, orderPaid))
+
+	err := m.Transition(orderNew, orderShipped)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusConflict, err.StatusCode())
+	assert.EqualValues(t, "Transition from New to Shipped is not allowed", err.Message())
+
+	err = m.Transition(orderNew, 42)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestStateMachineAllowUnknownStateReturnsNotFound(t *testing.T) {
+	m := orderFlow()
+
+	err := m.Allow(orderShipped, 42)
+
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+	assert.True(t, m.IsTerminal(orderShipped))
+	assert.EqualValues(t, http.StatusNotFound, m.SetInitial(42).StatusCode())
+}
+
+func TestStateMachineAllowIgnoresDuplicates(t *testing.T) {
+	m := orderFlow()
+	assert.Nil(t, m.Allow(orderNew, orderPaid))
+	assert.EqualValues(t, []int32{orderPaid, orderCancelled}, m.Next(orderNew))
+}
+
+func TestStateMachineInitialAndTerminalStates(t *testing.T) {
+	m := orderFlow()
+
+	assert.True(t, m.IsInitial(orderNew))
+	assert.False(t, m.IsInitial(orderPaid))
+	assert.False(t, m.IsTerminal(orderPaid))
+	assert.False(t, m.IsTerminal(42))
+	assert.EqualValues(t, []int32{orderNew}, m.InitialStates())
+	assert.EqualValues(t, []int32{orderShipped, orderCancelled}, m.TerminalStates())
+}
+
+func TestStateMachineDOT(t *testing.T) {
+	expected := `digraph "Orders" {
+	start [shape=point];
+	s0 [label="New", shape=circle];
+	s1 [label="Paid", shape=circle];
+	s2 [label="Shipped", shape=doublecircle];
+	s3 [label="in review", shape=doublecircle];
+	start -> s0;
+	s0 -> s1;
+	s0 -> s3;
+	s1 -> s2;
+	s1 -> s3;
+}
+`
+	assert.EqualValues(t, expected, orderFlow().DOT("Orders"))
+}
+
+func TestStateMachineMermaid(t *testing.T) {
+	expected := `stateDiagram-v2
+    state "in review" as InReview
+    [*] --> New
+    New --> Paid
+    New --> InReview
+    Paid --> Shipped
+    Paid --> InReview
+    Shipped --> [*]
+    InReview --> [*]
+`
+	assert.EqualValues(t, expected, orderFlow().Mermaid())
+}
+
+func TestStateMachineMermaidKeepsCollidingStatesApart(t *testing.T) {
+	m := NewStateMachine(&Enum{Items: []EnumItem{{Idx: 0, Val: "In Progress"}, {Idx: 1, Val: "in-progress"}, {Idx: 2, Val: "Done"}}})
+	_ = m.Allow(0, 1)
+	_ = m.Allow(1, 2)
+
+	expected := `stateDiagram-v2
+    state "In Progress" as state0
+    state "in-progress" as state1
+    state0 --> state1
+    state1 --> Done
+    Done --> [*]
+`
+	assert.EqualValues(t, expected, m.Mermaid())
+}
+
+func TestStateMachineDOTStartDoesNotCollideWithItems(t *testing.T) {
+	m := NewStateMachine(&Enum{Items: []EnumItem{{Idx: 0, Val: "__start"}, {Idx: 1, Val: "start"}}})
+	_ = m.SetInitial(1)
+	_ = m.Allow(0, 1)
+
+	dot := m.DOT("Flow")
+
+	assert.Contains(t, dot, "\tstart -> s1;\n")
+	assert.Contains(t, dot, "\ts0 [label=\"__start\", shape=circle];\n")
+	assert.Contains(t, dot, "\ts0 -> s1;\n")
+}
@@ -0,0 +1,63 @@
+package enums
+
+import (
+	"fmt"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	type AccountType int32
+//	var AccountTypes = NewTyped[AccountType](&Enum{Items: []EnumItem{{Idx: 0, Val: "Basic"}, {Idx: 1, Val: "Advanced"}}})
+//
+// Typed gives each enum its own Go type, so an AccountType cannot be passed where an OrderStatus is expected.
+// The enumgen command generates the type, its constants and the helper functions from a definition file.
+
+type Typed[T ~int32] struct {
+	Enum *Enum
+}
+
+func NewTyped[T ~int32](e *Enum) *Typed[T] {
+	return &Typed[T]{
+		Enum: e,
+	}
+}
+
+// String returns the value of v or "Type(index)" if v is not part of the enum.
+func (t *Typed[T]) String(v T) string {
+	val, err := t.Enum.AsValue(int32(v))
+	if err != nil {
+		return fmt.Sprintf("%T(%d)", v, v)
+	}
+	return val
+}
+
+func (t *Typed[T]) Parse(s string) (T, api_error.ApiErr) {
+	idx, err := t.Enum.AsIndex(s)
+	if err != nil {
+		return 0, err
+	}
+	return T(idx), nil
+}
+
+func (t *Typed[T]) Item(v T) (*EnumItem, api_error.ApiErr) {
+	return t.Enum.ItemByIndex(int32(v))
+}
+
+func (t *Typed[T]) IsValid(v T) bool {
+	_, err := t.Enum.ItemByIndex(int32(v))
+	return err == nil
+}
+
+func (t *Typed[T]) Values() []T {
+	values := make([]T, 0, len(t.Enum.Items))
+	for _, item := range t.Enum.Items {
+		values = append(values, T(item.Idx))
+	}
+	return values
+}
+
+func (t *Typed[T]) Names() []string {
+	return t.Enum.Values()
+}
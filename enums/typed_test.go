@@ -0,0 +1,54 @@
+package enums
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testColor int32
+
+var testColors = NewTyped[testColor](&Enum{
+	Items: []EnumItem{
+		{Idx: 0, Val: "Red"},
+		{Idx: 1, Val: "Green"},
+		{Idx: 5, Val: "Blue"},
+	},
+})
+
+func TestTypedStringReturnsValue(t *testing.T) {
+	assert.EqualValues(t, "Blue", testColors.String(5))
+}
+
+func TestTypedStringUnknownReturnsTypeAndIndex(t *testing.T) {
+	assert.EqualValues(t, "enums.testColor(3)", testColors.String(3))
+}
+
+func TestTypedParseReturnsTypedValue(t *testing.T) {
+	c, err := testColors.Parse("green")
+	assert.Nil(t, err)
+	assert.IsType(t, testColor(0), c)
+	assert.EqualValues(t, 1, c)
+}
+
+func TestTypedParseUnknownReturnsNotFoundError(t *testing.T) {
+	c, err := testColors.Parse("purple")
+	assert.EqualValues(t, 0, c)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestTypedValuesAndNames(t *testing.T) {
+	assert.EqualValues(t, []testColor{0, 1, 5}, testColors.Values())
+	assert.EqualValues(t, []string{"Red", "Green", "Blue"}, testColors.Names())
+}
+
+func TestTypedIsValidAndItem(t *testing.T) {
+	assert.True(t, testColors.IsValid(5))
+	assert.False(t, testColors.IsValid(2))
+
+	item, err := testColors.Item(1)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Green", item.Val)
+}
@@ -0,0 +1,192 @@
+package enums
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	type CreateAccountRequest struct {
+//		Type  string   `json:"type" enum:"AccountTypes"`
+//		Roles []int32  `json:"roles" enum:"Roles"`
+//		Tier  string   `json:"tier" enum:"Tiers,omitempty"`
+//	}
+//	if err := enums.ValidateStruct(req); err != nil { ... }
+//
+// String fields are checked by value, integer fields by index. Nil pointers and, with omitempty, empty strings
+// are skipped. Untagged struct fields, pointers to structs and slices of structs are validated recursively, each
+// pointer once.
+
+const tagName = "enum"
+
+// FieldError describes one invalid field; a list of them is returned as causes of the validation error.
+type FieldError struct {
+	Field string `json:"field"`
+	Enum  string `json:"enum"`
+	Value any    `json:"value"`
+	// Allowed lists the values clients should send, without deprecated items.
+	Allowed []string `json:"allowed"`
+}
+
+func ValidateStruct(v any) api_error.ApiErr {
+	return defaultRegistry.ValidateStruct(v)
+}
+
+// ValidateStruct validates all enum tagged fields of v, which must be a struct or a pointer to a struct.
+// All invalid fields are reported in one 422 error.
+func (r *Registry) ValidateStruct(v any) api_error.ApiErr {
+	rv := reflect.ValueOf(v)
+	seen := make(visited)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		seen[visit{ptr: rv.Pointer(), typ: rv.Type()}] = struct{}{}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return api_error.NewInternalServerError(fmt.Sprintf("cannot validate enums of %T", v), nil)
+	}
+	causes := make([]any, 0)
+	if err := r.validateStruct(rv, "", &causes, seen); err != nil {
+		return err
+	}
+	if len(causes) > 0 {
+		return api_error.NewError("invalid enum values", http.StatusUnprocessableEntity, causes)
+	}
+	return nil
+}
+
+// visited holds the pointers followed so far, so that self-referencing values are validated once.
+type visited map[visit]struct{}
+
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func (r *Registry) validateStruct(rv reflect.Value, prefix string, causes *[]any, seen visited) api_error.ApiErr {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix + fieldName(field)
+		tag, tagged := field.Tag.Lookup(tagName)
+		if !tagged {
+			if err := r.validateNested(rv.Field(i), path, causes, seen); err != nil {
+				return err
+			}
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		e, err := r.Lookup(name)
+		if err != nil {
+			return api_error.NewInternalServerError(fmt.Sprintf("enum %v of field %v is not registered", name, path), nil)
+		}
+		check := fieldCheck{
+			enum:      e,
+			enumName:  name,
+			omitEmpty: options == "omitempty",
+		}
+		if err := check.validate(rv.Field(i), path, causes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) validateNested(fv reflect.Value, path string, causes *[]any, seen visited) api_error.ApiErr {
+	switch fv.Kind() {
+	case reflect.Pointer:
+		if fv.IsNil() {
+			return nil
+		}
+		key := visit{ptr: fv.Pointer(), typ: fv.Type()}
+		if _, ok := seen[key]; ok {
+			return nil
+		}
+		seen[key] = struct{}{}
+		return r.validateNested(fv.Elem(), path, causes, seen)
+	case reflect.Struct:
+		return r.validateStruct(fv, path+".", causes, seen)
+	case reflect.Slice, reflect.Array:
+		for i := range fv.Len() {
+			if err := r.validateNested(fv.Index(i), fmt.Sprintf("%v[%v]", path, i), causes, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type fieldCheck struct {
+	enum      *Enum
+	enumName  string
+	omitEmpty bool
+}
+
+func (c fieldCheck) validate(fv reflect.Value, path string, causes *[]any) api_error.ApiErr {
+	switch fv.Kind() {
+	case reflect.Pointer:
+		if fv.IsNil() {
+			return nil
+		}
+		return c.validate(fv.Elem(), path, causes)
+	case reflect.Slice, reflect.Array:
+		for i := range fv.Len() {
+			if err := c.validate(fv.Index(i), fmt.Sprintf("%v[%v]", path, i), causes); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		if c.omitEmpty && fv.String() == "" {
+			return nil
+		}
+		if _, err := c.enum.ItemByValue(fv.String()); err != nil {
+			c.addCause(causes, path, fv.String())
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if idx := fv.Int(); idx < math.MinInt32 || idx > math.MaxInt32 || !c.hasIndex(int32(idx)) {
+			c.addCause(causes, path, idx)
+		}
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if idx := fv.Uint(); idx > math.MaxInt32 || !c.hasIndex(int32(idx)) {
+			c.addCause(causes, path, idx)
+		}
+		return nil
+	default:
+		return api_error.NewInternalServerError(fmt.Sprintf("field %v of type %v cannot be validated against an enum", path, fv.Type()), nil)
+	}
+}
+
+func (c fieldCheck) hasIndex(idx int32) bool {
+	_, err := c.enum.ItemByIndex(idx)
+	return err == nil
+}
+
+func (c fieldCheck) addCause(causes *[]any, path string, value any) {
+	*causes = append(*causes, FieldError{
+		Field:   path,
+		Enum:    c.enumName,
+		Value:   value,
+		Allowed: c.enum.ActiveValues(),
+	})
+}
+
+// fieldName returns the JSON name of the field, as clients know the field by that name.
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,146 @@
+package enums
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validateAddress struct {
+	Country string `json:"country" enum:"Countries"`
+}
+
+type validateRequest struct {
+	Type      string            `json:"type" enum:"AccountTypes"`
+	Level     int32             `json:"level" enum:"AccountTypes"`
+	Tier      string            `json:"tier,omitempty" enum:"AccountTypes,omitempty"`
+	Roles     []string          `enum:"AccountTypes"`
+	Optional  *string           `json:"optional" enum:"AccountTypes"`
+	Flags     []uint8           `json:"flags" enum:"AccountTypes"`
+	Address   validateAddress   `json:"address"`
+	Shipping  *validateAddress  `json:"shipping"`
+	Addresses []validateAddress `json:"addresses"`
+	Comment   string            `json:"comment"`
+	internal  string            `enum:"Unknown"`
+}
+
+func validateRegistry() *Registry {
+	r := NewRegistry()
+	_ = r.Register("AccountTypes", &Enum{Items: []EnumItem{{Idx: 0, Val: "Basic"}, {Idx: 1, Val: "Advanced"}}})
+	_ = r.Register("Countries", &Enum{Items: []EnumItem{{Idx: 0, Val: "DE"}, {Idx: 1, Val: "AT"}}})
+	return r
+}
+
+func validRequest() validateRequest {
+	return validateRequest{
+		Type:      "basic",
+		Level:     1,
+		Roles:     []string{"Basic", "ADVANCED"},
+		Flags:     []uint8{0},
+		Address:   validateAddress{Country: "DE"},
+		Addresses: []validateAddress{{Country: "at"}},
+		Comment:   "anything",
+		internal:  "ignored",
+	}
+}
+
+func TestValidateStructValidReturnsNoError(t *testing.T) {
+	req := validRequest()
+	assert.Nil(t, validateRegistry().ValidateStruct(req))
+	assert.Nil(t, validateRegistry().ValidateStruct(&req))
+}
+
+func TestValidateStructReportsAllInvalidFields(t *testing.T) {
+	req := validRequest()
+	req.Type = "Expert"
+	req.Level = 7
+	req.Roles = []string{"Basic", "Root"}
+	req.Optional = new("")
+	req.Flags = []uint8{0, 200}
+	req.Shipping = &validateAddress{Country: "CH"}
+	req.Addresses = append(req.Addresses, validateAddress{Country: "FR"})
+
+	err := validateRegistry().ValidateStruct(&req)
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, err.StatusCode())
+	assert.EqualValues(t, "invalid enum values", err.Message())
+	accountTypes := []string{"Basic", "Advanced"}
+	countries := []string{"DE", "AT"}
+	assert.EqualValues(t, []any{
+		FieldError{Field: "type", Enum: "AccountTypes", Value: "Expert", Allowed: accountTypes},
+		FieldError{Field: "level", Enum: "AccountTypes", Value: int64(7), Allowed: accountTypes},
+		FieldError{Field: "Roles[1]", Enum: "AccountTypes", Value: "Root", Allowed: accountTypes},
+		FieldError{Field: "optional", Enum: "AccountTypes", Value: "", Allowed: accountTypes},
+		FieldError{Field: "flags[1]", Enum: "AccountTypes", Value: uint64(200), Allowed: accountTypes},
+		FieldError{Field: "shipping.country", Enum: "Countries", Value: "CH", Allowed: countries},
+		FieldError{Field: "addresses[1].country", Enum: "Countries", Value: "FR", Allowed: countries},
+	}, err.Causes())
+}
+
+type validateNode struct {
+	Type string `json:"type" enum:"AccountTypes"`
+	Next *validateNode
+}
+
+func TestValidateStructAllowsOnlyActiveValues(t *testing.T) {
+	r := NewRegistry()
+	e := &Enum{Items: []EnumItem{{Idx: 0, Val: "DE"}, {Idx: 1, Val: "AT"}, {Idx: 2, Val: "YU"}}}
+	e.SetMetadata(2, ItemMetadata{Deprecated: true})
+	_ = r.Register("Countries", e)
+
+	err := r.ValidateStruct(validateAddress{Country: "CH"})
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, []any{FieldError{Field: "country", Enum: "Countries", Value: "CH", Allowed: []string{"DE", "AT"}}}, err.Causes())
+}
+
+func TestValidateStructStopsOnCycles(t *testing.T) {
+	node := &validateNode{Type: "Gold"}
+	node.Next = node
+
+	err := validateRegistry().ValidateStruct(node)
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, 1, len(err.Causes()))
+}
+
+func TestValidateStructUnregisteredEnumReturnsInternalError(t *testing.T) {
+	req := struct {
+		Type string `enum:"Unknown"`
+	}{}
+
+	err := validateRegistry().ValidateStruct(req)
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusInternalServerError, err.StatusCode())
+}
+
+func TestValidateStructUnsupportedFieldReturnsInternalError(t *testing.T) {
+	req := struct {
+		Type float64 `enum:"AccountTypes"`
+	}{}
+
+	err := validateRegistry().ValidateStruct(req)
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, http.StatusInternalServerError, err.StatusCode())
+}
+
+func TestValidateStructNonStructReturnsInternalError(t *testing.T) {
+	err := validateRegistry().ValidateStruct("text")
+	assert.EqualValues(t, http.StatusInternalServerError, err.StatusCode())
+}
+
+func TestValidateStructUsesDefaultRegistry(t *testing.T) {
+	_ = Register("ValidateDefaultTest", &Enum{Items: []EnumItem{{Idx: 0, Val: "On"}}})
+	req := struct {
+		State string `enum:"ValidateDefaultTest"`
+	}{State: "off"}
+
+	err := ValidateStruct(req)
+
+	assert.NotNil(t, err)
+	assert.EqualValues(t, "State", err.Causes()[0].(FieldError).Field)
+}
@@ -0,0 +1,224 @@
+package enums
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	type accountTypes struct{}
+//	func (accountTypes) Enum() *enums.Enum { return &AccountTypes }
+//	type AccountType = enums.Value[accountTypes]
+//
+// A Value can then be used directly in DTOs and database models; it is marshaled by name unless the binding
+// also implements FormatBinding. Unknown names or indexes are rejected with a NotFound error,
+// inputs of the wrong type with a Validation error.
+
+type Format int
+
+const (
+	FormatName Format = iota
+	FormatIndex
+)
+
+// Binding ties a Value type to its enum. It is usually implemented on an empty struct.
+type Binding interface {
+	Enum() *Enum
+}
+
+// FormatBinding is a Binding that chooses how its values are marshaled to JSON and SQL.
+type FormatBinding interface {
+	Binding
+	Format() Format
+}
+
+// Value is an enum item bound to its enum through B. The zero Value is unset and marshals to null.
+type Value[B Binding] struct {
+	idx   int32
+	valid bool
+}
+
+func NewValue[B Binding](i int32) (Value[B], api_error.ApiErr) {
+	var v Value[B]
+	if err := v.setIndex(i); err != nil {
+		return Value[B]{}, err
+	}
+	return v, nil
+}
+
+func ParseValue[B Binding](s string) (Value[B], api_error.ApiErr) {
+	var v Value[B]
+	if err := v.setValue(s); err != nil {
+		return Value[B]{}, err
+	}
+	return v, nil
+}
+
+func (v Value[B]) enum() *Enum {
+	var b B
+	return b.Enum()
+}
+
+func (v Value[B]) format() Format {
+	var b B
+	if f, ok := any(b).(FormatBinding); ok {
+		return f.Format()
+	}
+	return FormatName
+}
+
+func (v *Value[B]) setIndex(i int32) api_error.ApiErr {
+	item, err := v.enum().ItemByIndex(i)
+	if err != nil {
+		return err
+	}
+	v.idx, v.valid = item.Idx, true
+	return nil
+}
+
+func (v *Value[B]) setValue(s string) api_error.ApiErr {
+	item, err := v.enum().ItemByValue(s)
+	if err != nil {
+		return err
+	}
+	v.idx, v.valid = item.Idx, true
+	return nil
+}
+
+// setText accepts a name and, if no item has that name, an index.
+func (v *Value[B]) setText(s string) api_error.ApiErr {
+	err := v.setValue(s)
+	if err == nil {
+		return nil
+	}
+	if i, convErr := strconv.ParseInt(s, 10, 32); convErr == nil {
+		return v.setIndex(int32(i))
+	}
+	return err
+}
+
+func (v Value[B]) Valid() bool {
+	return v.valid
+}
+
+func (v Value[B]) Index() int32 {
+	return v.idx
+}
+
+func (v Value[B]) Item() (*EnumItem, api_error.ApiErr) {
+	if !v.valid {
+		return nil, api_error.NewNotFoundError("enum value is not set")
+	}
+	return v.enum().ItemByIndex(v.idx)
+}
+
+func (v Value[B]) String() string {
+	if !v.valid {
+		return ""
+	}
+	val, err := v.enum().AsValue(v.idx)
+	if err != nil {
+		return strconv.Itoa(int(v.idx))
+	}
+	return val
+}
+
+func (v Value[B]) MarshalJSON() ([]byte, error) {
+	if !v.valid {
+		return []byte("null"), nil
+	}
+	if v.format() == FormatIndex {
+		return json.Marshal(v.idx)
+	}
+	item, err := v.Item()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(item.Val)
+}
+
+// UnmarshalJSON accepts a name, an index or null, independent of the configured Format.
+func (v *Value[B]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*v = Value[B]{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return toError(v.setValue(s))
+	}
+	var i int32
+	if err := json.Unmarshal(data, &i); err == nil {
+		return toError(v.setIndex(i))
+	}
+	return api_error.NewValidationError(fmt.Sprintf("invalid enum value %s", data))
+}
+
+func (v Value[B]) MarshalText() ([]byte, error) {
+	if !v.valid {
+		return []byte{}, nil
+	}
+	item, err := v.Item()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(item.Val), nil
+}
+
+func (v *Value[B]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = Value[B]{}
+		return nil
+	}
+	return toError(v.setText(string(text)))
+}
+
+// Scan implements sql.Scanner for NULL, integer and text columns.
+func (v *Value[B]) Scan(src any) error {
+	switch s := src.(type) {
+	case nil:
+		*v = Value[B]{}
+		return nil
+	case int64:
+		if s < -1<<31 || s > 1<<31-1 {
+			return api_error.NewValidationError(fmt.Sprintf("enum index %v out of range", s))
+		}
+		return toError(v.setIndex(int32(s)))
+	case string:
+		return toError(v.setText(s))
+	case []byte:
+		return toError(v.setText(string(s)))
+	default:
+		return api_error.NewValidationError(fmt.Sprintf("cannot scan %T into enum value", src))
+	}
+}
+
+// Value implements driver.Valuer; it stores the index or the name depending on the configured Format.
+func (v Value[B]) Value() (driver.Value, error) {
+	if !v.valid {
+		return nil, nil
+	}
+	if v.format() == FormatIndex {
+		return int64(v.idx), nil
+	}
+	item, err := v.Item()
+	if err != nil {
+		return nil, err
+	}
+	return item.Val, nil
+}
+
+// toError avoids returning a typed nil api_error.ApiErr as a non-nil error.
+func toError(err api_error.ApiErr) error {
+	if err == nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+package enums
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+	"github.com/stretchr/testify/assert"
+)
+
+var valueTestEnum = Enum{
+	Items: []EnumItem{
+		{Idx: 0, Val: "Basic"},
+		{Idx: 1, Val: "Advanced"},
+	},
+}
+
+type byName struct{}
+
+func (byName) Enum() *Enum { return &valueTestEnum }
+
+type byIndex struct{}
+
+func (byIndex) Enum() *Enum    { return &valueTestEnum }
+func (byIndex) Format() Format { return FormatIndex }
+
+type accountDto struct {
+	Type  Value[byName]  `json:"type"`
+	Level Value[byIndex] `json:"level"`
+}
+
+var (
+	_ json.Marshaler           = Value[byName]{}
+	_ json.Unmarshaler         = &Value[byName]{}
+	_ encoding.TextMarshaler   = Value[byName]{}
+	_ encoding.TextUnmarshaler = &Value[byName]{}
+	_ sql.Scanner              = &Value[byName]{}
+	_ driver.Valuer            = Value[byName]{}
+)
+
+func TestNewValueAndParseValue(t *testing.T) {
+	v, err := NewValue[byName](1)
+	assert.Nil(t, err)
+	assert.True(t, v.Valid())
+	assert.EqualValues(t, 1, v.Index())
+	assert.EqualValues(t, "Advanced", v.String())
+
+	p, err := ParseValue[byName]("basic")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, p.Index())
+
+	_, err = NewValue[byName](7)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+	_, err = ParseValue[byName]("expert")
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+}
+
+func TestValueMarshalJSONUsesFormat(t *testing.T) {
+	dto := accountDto{}
+	dto.Type, _ = NewValue[byName](1)
+	dto.Level, _ = NewValue[byIndex](1)
+
+	data, err := json.Marshal(dto)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, `{"type":"Advanced","level":1}`, string(data))
+}
+
+func TestValueMarshalJSONUnsetReturnsNull(t *testing.T) {
+	data, err := json.Marshal(accountDto{})
+	assert.Nil(t, err)
+	assert.EqualValues(t, `{"type":null,"level":null}`, string(data))
+}
+
+func TestValueUnmarshalJSONAcceptsNameAndIndex(t *testing.T) {
+	var dto accountDto
+
+	err := json.Unmarshal([]byte(`{"type": 1, "level": "basic"}`), &dto)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Advanced", dto.Type.String())
+	assert.EqualValues(t, 0, dto.Level.Index())
+	assert.True(t, dto.Level.Valid())
+}
+
+func TestValueUnmarshalJSONNullResetsValue(t *testing.T) {
+	v, _ := NewValue[byName](1)
+	err := json.Unmarshal([]byte("null"), &v)
+	assert.Nil(t, err)
+	assert.False(t, v.Valid())
+}
+
+func TestValueUnmarshalJSONUnknownReturnsNotFound(t *testing.T) {
+	var dto accountDto
+
+	err := json.Unmarshal([]byte(`{"type": "expert"}`), &dto)
+
+	var apiErr api_error.ApiErr
+	assert.ErrorAs(t, err, &apiErr)
+	assert.EqualValues(t, http.StatusNotFound, apiErr.StatusCode())
+}
+
+func TestValueUnmarshalJSONWrongTypeReturnsValidationError(t *testing.T) {
+	var v Value[byName]
+
+	err := json.Unmarshal([]byte(`true`), &v)
+
+	var apiErr api_error.ApiErr
+	assert.ErrorAs(t, err, &apiErr)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, apiErr.StatusCode())
+}
+
+func TestValueText(t *testing.T) {
+	var v Value[byIndex]
+	assert.Nil(t, v.UnmarshalText([]byte("advanced")))
+	text, err := v.MarshalText()
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Advanced", string(text))
+
+	assert.Nil(t, v.UnmarshalText([]byte("0")))
+	assert.EqualValues(t, 0, v.Index())
+
+	assert.NotNil(t, v.UnmarshalText([]byte("expert")))
+	assert.Nil(t, v.UnmarshalText(nil))
+	assert.False(t, v.Valid())
+	text, _ = v.MarshalText()
+	assert.Empty(t, text)
+}
+
+func TestValueScan(t *testing.T) {
+	var v Value[byName]
+
+	assert.Nil(t, v.Scan(int64(1)))
+	assert.EqualValues(t, 1, v.Index())
+	assert.Nil(t, v.Scan("basic"))
+	assert.EqualValues(t, 0, v.Index())
+	assert.Nil(t, v.Scan([]byte("Advanced")))
+	assert.EqualValues(t, 1, v.Index())
+	assert.Nil(t, v.Scan(nil))
+	assert.False(t, v.Valid())
+
+	var apiErr api_error.ApiErr
+	assert.ErrorAs(t, v.Scan(int64(9)), &apiErr)
+	assert.EqualValues(t, http.StatusNotFound, apiErr.StatusCode())
+	assert.ErrorAs(t, v.Scan(int64(1)<<40), &apiErr)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, apiErr.StatusCode())
+	assert.ErrorAs(t, v.Scan(1.5), &apiErr)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, apiErr.StatusCode())
+}
+
+func TestValueDriverValueUsesFormat(t *testing.T) {
+	name, _ := NewValue[byName](1)
+	index, _ := NewValue[byIndex](1)
+
+	nameVal, err1 := name.Value()
+	indexVal, err2 := index.Value()
+	unsetVal, err3 := Value[byName]{}.Value()
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.EqualValues(t, "Advanced", nameVal)
+	assert.EqualValues(t, int64(1), indexVal)
+	assert.Nil(t, unsetVal)
+}
+
+func TestValueItemUnsetReturnsNotFound(t *testing.T) {
+	item, err := Value[byName]{}.Item()
+	assert.Nil(t, item)
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode())
+	assert.EqualValues(t, "", Value[byName]{}.String())
+}
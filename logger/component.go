@@ -0,0 +1,260 @@
+package logger
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Usage:
+//
+//	db := logger.Named("db")
+//	db.Debug("query", logger.Field{Key: "sql", Value: q})
+//
+// Components are configured with ConfigureComponent or with environment variables named after the component
+// in upper case, e.g. for "db.pool":
+//
+//	LOG_LEVEL_DB_POOL=debug
+//	LOG_OUTPUT_DB_POOL=/var/log/app/db.log
+//	LOG_SAMPLING_DB_POOL=100,10 (the first 100 entries per second and message, then every 10th, see SamplingConfig)
+//
+// Settings are inherited from parent components ("db" for "db.pool"), the closest component wins. For the same
+// component, configuration from code takes precedence over the environment. Components without an output write
+// to the default output. Levels from the environment are read by initLogger and are not listed by
+// ComponentLevels, so ResetComponentLevel falls back to them.
+
+type ComponentConfig struct {
+	Level string
+	// Output replaces the default output, e.g. "stderr" or a file path.
+	Output string
+	// Sampling replaces the sampling of the logger.
+	Sampling *SamplingConfig
+}
+
+// ConfigureComponent sets the level, output and sampling of a component and its children. Call it during
+// start-up, as loggers of the component are rebuilt and their previous outputs closed.
+func ConfigureComponent(name string, cfg ComponentConfig) error {
+	return root.ConfigureComponent(name, cfg)
+}
+
+func (l *Logger) ConfigureComponent(name string, cfg ComponentConfig) error {
+	if name = strings.TrimSpace(name); name == "" {
+		return fmt.Errorf("component must not be empty")
+	}
+	if cfg.Sampling != nil {
+		if err := cfg.Sampling.validate(); err != nil {
+			return err
+		}
+	}
+	if cfg.Level != "" {
+		if err := l.SetComponentLevel(name, cfg.Level); err != nil {
+			return err
+		}
+	}
+	if cfg.Output != "" {
+		if err := validateOutput(cfg.Output); err != nil {
+			return err
+		}
+	}
+	inst := l.instance()
+	inst.componentConfigMu.Lock()
+	inst.componentConfigs[name] = cfg
+	inst.componentConfigMu.Unlock()
+	inst.closeComponentOutputs()
+	return nil
+}
+
+// envName returns the environment variable suffix of a component, e.g. "DB_POOL" for "db.pool".
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func parentName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// envComponentLevels reads the LOG_LEVEL_<COMPONENT> variables, keyed by the component suffix, e.g. "DB_POOL".
+// Invalid levels are ignored.
+func envComponentLevels() map[string]zapcore.Level {
+	levels := make(map[string]zapcore.Level)
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		suffix, ok := strings.CutPrefix(key, envLogLevel+"_")
+		if !ok || suffix == "" {
+			continue
+		}
+		if lvl, err := parseLevel(value); err == nil {
+			levels[suffix] = lvl
+		}
+	}
+	return levels
+}
+
+// validateOutput checks that an output can be opened without creating it: file paths need an existing
+// directory and must not be directories themselves. Outputs with other schemes are checked when opened.
+func validateOutput(output string) error {
+	if output == "stdout" || output == "stderr" {
+		return nil
+	}
+	path := output
+	// One letter schemes are Windows drive letters.
+	if u, err := url.Parse(output); err == nil && len(u.Scheme) > 1 {
+		if u.Scheme != "file" {
+			return nil
+		}
+		path = u.Path
+	}
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("output %v is a directory", output)
+		}
+		return nil
+	}
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || !info.IsDir() {
+		return fmt.Errorf("could not open output %v: directory %v does not exist", output, filepath.Dir(path))
+	}
+	return nil
+}
+
+// resolvedComponent is the merged configuration of a component. outputFrom and samplingFrom name the components
+// that set the output and sampling, so that their children share them.
+type resolvedComponent struct {
+	ComponentConfig
+	outputFrom   string
+	samplingFrom string
+}
+
+// resolveComponent merges the output and sampling of the component and its parents, the closest setting wins.
+// Levels are resolved on every entry by levelFor. The caller holds componentConfigMu.
+func (inst *instance) resolveComponent(name string) resolvedComponent {
+	var resolved resolvedComponent
+	for n := name; n != ""; n = parentName(n) {
+		cfg := inst.componentConfigs[n]
+		suffix := "_" + envName(n)
+		if cfg.Output == "" {
+			cfg.Output = strings.TrimSpace(os.Getenv(envLogOutput + suffix))
+		}
+		if cfg.Sampling == nil {
+			if value := os.Getenv(envLogSampling + suffix); value != "" {
+				var err error
+				if cfg.Sampling, err = parseSampling(value); err != nil {
+					(&Logger{inst: inst}).Error(fmt.Sprintf("ignoring %v", envLogSampling+suffix), err)
+				}
+			}
+		}
+		if resolved.Output == "" && cfg.Output != "" {
+			resolved.Output, resolved.outputFrom = cfg.Output, n
+		}
+		if resolved.Sampling == nil && cfg.Sampling != nil {
+			resolved.Sampling, resolved.samplingFrom = cfg.Sampling, n
+		}
+	}
+	return resolved
+}
+
+// componentLogger is the zap logger and sampler of a component.
+type componentLogger struct {
+	log     *zap.Logger
+	sampler *sampler
+}
+
+// componentLogger returns the logger for a component name. Up to maxNamedLoggers component loggers are cached
+// until the next initLogger or ConfigureComponent; beyond that they are built for every entry. Outputs and
+// samplers are opened once per configuring component and shared by its children.
+func (inst *instance) componentLogger(name string) *componentLogger {
+	if name == "" {
+		return inst.out.Load().root
+	}
+	if cl, ok := inst.namedLoggers.Load(name); ok {
+		return cl.(*componentLogger)
+	}
+	inst.componentConfigMu.Lock()
+	defer inst.componentConfigMu.Unlock()
+	if cl, ok := inst.namedLoggers.Load(name); ok {
+		return cl.(*componentLogger)
+	}
+	cfg := inst.resolveComponent(name)
+	shared := inst.sharedComponentLogger(cfg)
+	cl := &componentLogger{
+		log:     shared.log.Named(name),
+		sampler: shared.sampler,
+	}
+	if inst.namedLoggerCount < maxNamedLoggers {
+		inst.namedLoggers.Store(name, cl)
+		inst.namedLoggerCount++
+	}
+	return cl
+}
+
+// sharedComponentLogger returns the unnamed logger with the output and sampler of cfg, opening them on first use.
+// The caller holds componentConfigMu.
+func (inst *instance) sharedComponentLogger(cfg resolvedComponent) *componentLogger {
+	out := inst.out.Load()
+	if cfg.Output == "" && cfg.Sampling == nil {
+		return out.root
+	}
+	key := [2]string{cfg.outputFrom, cfg.samplingFrom}
+	if cl, ok := inst.componentOutputs[key]; ok {
+		return cl
+	}
+	cl := &componentLogger{
+		log:     out.log,
+		sampler: out.root.sampler,
+	}
+	var closers []func()
+	if cfg.Output != "" {
+		ws, closeOutput, err := zap.Open(cfg.Output)
+		if err != nil {
+			(&Logger{inst: inst}).Error(fmt.Sprintf("could not open output %v of component %v", cfg.Output, cfg.outputFrom), err)
+		} else {
+			// The encoding was validated when the output was configured.
+			enc, _ := newEncoder(out.encoding, terminals(cfg.Output))
+			cl.log = zap.New(levelCore{
+				Core: zapcore.NewCore(enc, ws, zap.DebugLevel),
+				inst: inst,
+			}, out.options...)
+			closers = append(closers, closeOutput)
+		}
+	}
+	if cfg.Sampling != nil {
+		cl.sampler = inst.newSampler(*cfg.Sampling, cl.log)
+		// The summaries are written before the output is closed.
+		closers = append([]func(){cl.sampler.flush}, closers...)
+	}
+	if inst.componentOutputs == nil {
+		inst.componentOutputs = make(map[[2]string]*componentLogger)
+	}
+	inst.componentOutputs[key] = cl
+	inst.componentClosers = append(inst.componentClosers, closers...)
+	return cl
+}
+
+// closeComponentOutputs drops the cached component loggers, writes their sampling summaries and closes their
+// outputs.
+func (inst *instance) closeComponentOutputs() {
+	inst.componentConfigMu.Lock()
+	defer inst.componentConfigMu.Unlock()
+	inst.namedLoggers.Clear()
+	inst.namedLoggerCount = 0
+	clear(inst.componentOutputs)
+	for _, closeOutput := range inst.componentClosers {
+		closeOutput()
+	}
+	inst.componentClosers = nil
+}
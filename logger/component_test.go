@@ -0,0 +1,224 @@
+package logger
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetComponents(t *testing.T) {
+	resetLevels(t)
+	t.Cleanup(func() {
+		std.componentConfigMu.Lock()
+		clear(std.componentConfigs)
+		std.componentConfigMu.Unlock()
+		std.closeComponentOutputs()
+		std.envLevels.Store(nil)
+	})
+}
+
+func TestNamedWritesLoggerField(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+
+	Named("http").Info(infoMsg)
+
+	m := extractLog()
+	assert.EqualValues(t, "http", m["logger"])
+	assert.Contains(t, m["caller"], "logger/component_test.go")
+}
+
+func TestNamedRecordsComponentInLogList(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	ClearLogList()
+
+	Named("db").Named("pool").Info("pool exhausted")
+	Named("http").Info("request")
+	Info("root")
+
+	l := GetLogList()
+	assert.EqualValues(t, "db.pool", l[0].Component)
+	assert.EqualValues(t, "", l[2].Component)
+	assert.EqualValues(t, []string{"pool exhausted"}, messages(QueryLogList(Query{Filter: Filter{Components: []string{"db"}}}).Entries))
+	assert.EqualValues(t, []string{"request"}, messages(QueryLogList(Query{Filter: Filter{Components: []string{"http", "d"}}}).Entries))
+}
+
+func TestHandlerFiltersByComponent(t *testing.T) {
+	ClearLogList()
+	Named("db").Info("query")
+	Named("http").Info("request")
+
+	rec := serveLogs(Handler(HandlerOptions{}), http.MethodGet, "/logs?component=db", nil)
+
+	assert.Contains(t, rec.Body.String(), `"LogMessage":"query"`)
+	assert.NotContains(t, rec.Body.String(), `"LogMessage":"request"`)
+}
+
+func TestComponentLevelFromEnvironment(t *testing.T) {
+	resetComponents(t)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_LEVEL_DB", "debug")
+	initLogger(true, FileOptions{})
+
+	Named("db").Named("pool").Debug(debugMsg)
+
+	assert.EqualValues(t, debugMsg, extractLog()["msg"])
+	assert.Empty(t, ComponentLevels())
+}
+
+func TestResetComponentLevelFallsBackToEnvironment(t *testing.T) {
+	resetComponents(t)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_LEVEL_DB", "debug")
+	initLogger(true, FileOptions{})
+	_ = SetComponentLevel("db", "error")
+
+	ResetComponentLevel("db")
+	Named("db").Debug(debugMsg)
+
+	assert.EqualValues(t, debugMsg, extractLog()["msg"])
+	assert.Empty(t, ComponentLevels())
+}
+
+func TestComponentLevelFromEnvironmentKeepsExplicitLevel(t *testing.T) {
+	resetComponents(t)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_LEVEL_DB", "debug")
+	initLogger(true, FileOptions{})
+	_ = SetComponentLevel("db", "error")
+
+	Named("db").Warn(warnMsg)
+
+	assert.Empty(t, sink.String())
+}
+
+func TestComponentOutputFromEnvironment(t *testing.T) {
+	resetComponents(t)
+	out := filepath.Join(t.TempDir(), "db.log")
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_OUTPUT_DB", out)
+	initLogger(true, FileOptions{})
+
+	Named("db").Info("query")
+	Named("http").Info("request")
+
+	data, err := os.ReadFile(out)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"msg":"query"`)
+	assert.Contains(t, string(data), `"logger":"db"`)
+	assert.NotContains(t, sink.String(), "query")
+	assert.Contains(t, sink.String(), "request")
+}
+
+func TestConfigureComponentSetsOutputLevelAndSampling(t *testing.T) {
+	resetComponents(t)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_OUTPUT_DB", filepath.Join(t.TempDir(), "ignored.log"))
+	initLogger(true, FileOptions{})
+	out := filepath.Join(t.TempDir(), "db.log")
+
+	err := ConfigureComponent("db", ComponentConfig{Level: "debug", Output: out, Sampling: &SamplingConfig{First: 2}})
+	for range 5 {
+		Named("db").Named("pool").Debug(debugMsg)
+	}
+
+	assert.Nil(t, err)
+	data, _ := os.ReadFile(out)
+	assert.EqualValues(t, 2, strings.Count(string(data), debugMsg))
+	assert.Empty(t, sink.String())
+}
+
+func TestComponentSamplingFromEnvironment(t *testing.T) {
+	resetComponents(t)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_SAMPLING_HTTP", "1,0")
+	initLogger(true, FileOptions{})
+
+	for range 3 {
+		Named("http").Info(infoMsg)
+	}
+	for range 3 {
+		Named("db").Info(warnMsg)
+	}
+
+	assert.EqualValues(t, 1, strings.Count(sink.String(), infoMsg))
+	assert.EqualValues(t, 3, strings.Count(sink.String(), warnMsg))
+}
+
+func TestConfigureComponentOpensOutputOnFirstUse(t *testing.T) {
+	resetComponents(t)
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	out := filepath.Join(t.TempDir(), "db.log")
+
+	assert.Nil(t, ConfigureComponent("db", ComponentConfig{Output: out}))
+	_, err := os.Stat(out)
+	assert.True(t, os.IsNotExist(err))
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			Named("db").Named(strconv.Itoa(i)).Info("query")
+		})
+	}
+	wg.Wait()
+
+	data, _ := os.ReadFile(out)
+	assert.EqualValues(t, 8, strings.Count(string(data), `"msg":"query"`))
+	std.componentConfigMu.Lock()
+	defer std.componentConfigMu.Unlock()
+	assert.EqualValues(t, 1, len(std.componentOutputs))
+	assert.EqualValues(t, 1, len(std.componentClosers))
+}
+
+func TestComponentLoggerCacheIsBounded(t *testing.T) {
+	resetComponents(t)
+	t.Setenv("LOG_LEVEL", "error")
+	initLogger(true, FileOptions{})
+
+	for i := range maxNamedLoggers + 10 {
+		Named("request").Named(strconv.Itoa(i)).Info(infoMsg)
+	}
+	Named("request").Named(strconv.Itoa(maxNamedLoggers+5)).Error(errorMsg, nil)
+
+	std.componentConfigMu.Lock()
+	defer std.componentConfigMu.Unlock()
+	assert.EqualValues(t, maxNamedLoggers, std.namedLoggerCount)
+	assert.Contains(t, sink.String(), errorMsg)
+}
+
+func TestConfigureComponentRejectsInvalidConfig(t *testing.T) {
+	resetComponents(t)
+
+	assert.NotNil(t, ConfigureComponent(" ", ComponentConfig{}))
+	assert.NotNil(t, ConfigureComponent("db", ComponentConfig{Level: "verbose"}))
+	assert.NotNil(t, ConfigureComponent("db", ComponentConfig{Output: filepath.Join(t.TempDir(), "missing", "db.log")}))
+	assert.NotNil(t, ConfigureComponent("db", ComponentConfig{Output: t.TempDir()}))
+}
+
+func TestParseSampling(t *testing.T) {
+	s, err := parseSampling("100, 10")
+	assert.Nil(t, err)
+	assert.EqualValues(t, &SamplingConfig{First: 100, Thereafter: 10}, s)
+
+	s, err = parseSampling("OFF")
+	assert.Nil(t, err)
+	assert.EqualValues(t, &SamplingConfig{}, s)
+
+	_, err = parseSampling("many")
+	assert.NotNil(t, err)
+	_, err = parseSampling("1,x")
+	assert.NotNil(t, err)
+}
+
+func TestEnvName(t *testing.T) {
+	assert.EqualValues(t, "DB_POOL", envName("db.pool"))
+	assert.EqualValues(t, "HTTP_V2_API", envName("http-v2.Api"))
+}
@@ -0,0 +1,195 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Usage:
+//
+//	// in middleware
+//	ctx := logger.ContextWithFields(r.Context(), logger.Field{Key: "requestId", Value: id})
+//	next.ServeHTTP(w, r.WithContext(ctx))
+//
+//	// in a handler
+//	logger.WithContext(r.Context()).Info("order created", logger.Field{Key: "orderId", Value: order.Id})
+//
+// Every entry written through the returned Logger carries the context fields, in the output and in the log list.
+
+type fieldsKey struct{}
+
+// Logger writes log entries with a fixed set of fields. The zero value logs through the default logger
+// without extra fields.
+type Logger struct {
+	inst   *instance
+	name   string
+	fields []Field
+}
+
+var (
+	root = &Logger{}
+)
+
+var levelNames = map[zapcore.Level]string{
+	zap.DebugLevel: "Debug",
+	zap.InfoLevel:  "Info",
+	zap.WarnLevel:  "Warn",
+	zap.ErrorLevel: "Error",
+}
+
+// ContextWithFields returns a copy of ctx that carries the given fields in addition to those already stored.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, appendFields(FieldsFromContext(ctx), fields))
+}
+
+// FieldsFromContext returns the fields stored in ctx by ContextWithFields.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]Field)
+	return fields
+}
+
+// Named returns a logger for a component, e.g. "db". Its entries carry the name in the "logger" field
+// and follow the level set by SetComponentLevel.
+func Named(name string) *Logger {
+	return root.Named(name)
+}
+
+// With returns a child logger that adds the given fields to every entry.
+func With(fields ...Field) *Logger {
+	return root.With(fields...)
+}
+
+// WithContext returns a child logger that adds the fields stored in ctx to every entry.
+func WithContext(ctx context.Context) *Logger {
+	return root.WithContext(ctx)
+}
+
+func (l *Logger) With(fields ...Field) *Logger {
+	return &Logger{
+		inst:   l.inst,
+		name:   l.name,
+		fields: appendFields(l.fields, fields),
+	}
+}
+
+// Named returns a child logger; names are joined with dots, e.g. "db.pool".
+func (l *Logger) Named(name string) *Logger {
+	switch {
+	case name == "":
+		name = l.name
+	case l.name != "":
+		name = l.name + "." + name
+	}
+	return &Logger{
+		inst:   l.inst,
+		name:   name,
+		fields: l.fields,
+	}
+}
+
+func (l *Logger) instance() *instance {
+	if l.inst == nil {
+		return std
+	}
+	return l.inst
+}
+
+// Name returns the component name, empty for the root logger.
+func (l *Logger) Name() string {
+	return l.name
+}
+
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	return l.With(FieldsFromContext(ctx)...)
+}
+
+// Fields returns the fields the logger adds to every entry.
+func (l *Logger) Fields() []Field {
+	return append([]Field(nil), l.fields...)
+}
+
+// Debugf writes only to the zap logger and intentionally skips the in-memory log list.
+func (l *Logger) Debugf(msg string, a ...any) {
+	l.write(zap.DebugLevel, fmt.Sprintf(msg, a...), nil, false, nil)
+}
+
+func (l *Logger) Debug(msg string, tags ...Field) {
+	l.write(zap.DebugLevel, msg, nil, true, tags)
+}
+
+func (l *Logger) Infof(msg string, a ...any) {
+	l.write(zap.InfoLevel, fmt.Sprintf(msg, a...), nil, true, nil)
+}
+
+func (l *Logger) Info(msg string, tags ...Field) {
+	l.write(zap.InfoLevel, msg, nil, true, tags)
+}
+
+func (l *Logger) Warnf(msg string, a ...any) {
+	l.write(zap.WarnLevel, fmt.Sprintf(msg, a...), nil, true, nil)
+}
+
+func (l *Logger) Warn(msg string, tags ...Field) {
+	l.write(zap.WarnLevel, msg, nil, true, tags)
+}
+
+func (l *Logger) Errorf(msg string, a ...any) {
+	l.write(zap.ErrorLevel, fmt.Sprintf(msg, a...), nil, true, nil)
+}
+
+func (l *Logger) Error(msg string, err error, tags ...Field) {
+	l.write(zap.ErrorLevel, msg, err, true, tags)
+}
+
+// write is called directly by the exported functions and methods; the zap logger skips both frames when
+// reporting the caller.
+func (l *Logger) write(level zapcore.Level, msg string, err error, list bool, tags []Field) {
+	inst := l.instance()
+	cl := inst.componentLogger(l.name)
+	// Entries below the level only go to the log list, the sampler counts them apart from the written ones.
+	enabled := level >= inst.levelFor(l.name)
+	if !enabled && !list || !cl.sampler.allow(l.name, level, msg, !enabled) {
+		return
+	}
+	fields := appendFields(l.fields, tags)
+	if list {
+		entry := LogEntry{
+			Component:  l.name,
+			LogLevel:   levelNames[level],
+			LogMessage: msg,
+			Fields:     fields,
+		}
+		if inst.out.Load().caller {
+			entry.Caller = zapcore.NewEntryCaller(runtime.Caller(2)).TrimmedPath()
+		}
+		if err != nil {
+			entry.LogMessage = msg + ": " + err.Error()
+			entry.Error = err.Error()
+		}
+		inst.addEntry(entry)
+	}
+	if !enabled {
+		return
+	}
+	zapTags := fieldsToZapField(fields)
+	if err != nil {
+		zapTags = append(zapTags, zap.NamedError("error", err))
+	}
+	cl.log.Log(level, msg, zapTags...)
+	cl.log.Sync()
+}
+
+// appendFields returns a new slice, so loggers and contexts never share a backing array.
+func appendFields(base, fields []Field) []Field {
+	if len(fields) == 0 {
+		return base
+	}
+	return append(append(make([]Field, 0, len(base)+len(fields)), base...), fields...)
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithFieldsAppendsFields(t *testing.T) {
+	ctx := ContextWithFields(context.Background(), Field{Key: "requestId", Value: "r1"})
+	child := ContextWithFields(ctx, Field{Key: "userId", Value: "u1"})
+
+	assert.EqualValues(t, []Field{{Key: "requestId", Value: "r1"}}, FieldsFromContext(ctx))
+	assert.EqualValues(t, []Field{{Key: "requestId", Value: "r1"}, {Key: "userId", Value: "u1"}}, FieldsFromContext(child))
+}
+
+func TestFieldsFromContextWithoutFieldsReturnsNil(t *testing.T) {
+	assert.Nil(t, FieldsFromContext(context.Background()))
+}
+
+func TestWithContextInfoWritesContextFields(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	ClearLogList()
+	ctx := ContextWithFields(context.Background(), Field{Key: "requestId", Value: "r1"}, Field{Key: "tenantId", Value: "t1"})
+
+	WithContext(ctx).Info(infoMsg, Field{Key: "id", Value: "123"})
+
+	m := extractLog()
+	assert.EqualValues(t, infoMsg, m["msg"])
+	assert.EqualValues(t, "r1", m["requestId"])
+	assert.EqualValues(t, "t1", m["tenantId"])
+	assert.EqualValues(t, "123", m["id"])
+	assert.Contains(t, m["caller"], "logger/context_test.go")
+	l := GetLogList()
+	assert.EqualValues(t, 1, len(l))
+	assert.EqualValues(t, []Field{{Key: "requestId", Value: "r1"}, {Key: "tenantId", Value: "t1"}, {Key: "id", Value: "123"}}, l[0].Fields)
+}
+
+func TestWithContextErrorWritesContextFieldsAndError(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	ClearLogList()
+	ctx := ContextWithFields(context.Background(), Field{Key: "requestId", Value: "r1"})
+
+	WithContext(ctx).Error(errorMsg, errors.New(newErrorMsg))
+
+	m := extractLog()
+	assert.EqualValues(t, "error", m["level"])
+	assert.EqualValues(t, "r1", m["requestId"])
+	assert.EqualValues(t, newErrorMsg, m["error"])
+	l := GetLogList()
+	assert.EqualValues(t, errorMsg+": "+newErrorMsg, l[0].LogMessage)
+	assert.EqualValues(t, []Field{{Key: "requestId", Value: "r1"}}, l[0].Fields)
+}
+
+func TestWithAddsFieldsToChildOnly(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	parent := With(Field{Key: "service", Value: "orders"})
+	child := parent.With(Field{Key: "userId", Value: "u1"})
+
+	child.Warnf("%v", warnMsg)
+
+	m := extractLog()
+	assert.EqualValues(t, "warn", m["level"])
+	assert.EqualValues(t, "orders", m["service"])
+	assert.EqualValues(t, "u1", m["userId"])
+	assert.EqualValues(t, []Field{{Key: "service", Value: "orders"}}, parent.Fields())
+}
+
+func TestWithDoesNotShareFields(t *testing.T) {
+	parent := With(Field{Key: "a", Value: 1}, Field{Key: "b", Value: 2})
+	first := parent.With(Field{Key: "c", Value: 3})
+	second := parent.With(Field{Key: "d", Value: 4})
+
+	assert.EqualValues(t, "c", first.Fields()[2].Key)
+	assert.EqualValues(t, "d", second.Fields()[2].Key)
+}
+
+func TestLoggerDebugfSkipsLogList(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	initLogger(true, FileOptions{})
+	ClearLogList()
+
+	With(Field{Key: "requestId", Value: "r1"}).Debugf("%v", debugMsg)
+
+	m := extractLog()
+	assert.EqualValues(t, debugMsg, m["msg"])
+	assert.EqualValues(t, "r1", m["requestId"])
+	assert.Empty(t, GetLogList())
+}
+
+func TestGlobalInfoHasNoFieldsInLogList(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	ClearLogList()
+
+	Info(infoMsg)
+
+	assert.Nil(t, GetLogList()[0].Fields)
+	assert.Contains(t, extractLog()["caller"], "logger/context_test.go")
+}
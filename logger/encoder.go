@@ -0,0 +1,274 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Usage:
+//
+//	LOG_FORMAT=console go run .
+//
+// The output is JSON by default. For local development, console writes colored, aligned lines:
+//
+//	15:04:05.000 INFO  orders/handler.go:42     [db] order created orderId=42 customer.name="ACME Inc." items=[a b]
+//
+// logfmt writes key=value lines for log shippers that expect them:
+//
+//	time=2026-10-19T15:04:05Z level=info logger=db caller=orders/handler.go:42 msg="order created" orderId=42
+//
+// Colors are only written to outputs that are terminals, and left out if NO_COLOR is set.
+
+const (
+	envLogFormat = "LOG_FORMAT"
+	callerWidth  = 24
+)
+
+const (
+	colorReset   = "\x1b[0m"
+	colorRed     = "\x1b[31m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+	colorGray    = "\x1b[90m"
+)
+
+var (
+	textPool    = buffer.NewPool()
+	levelColors = map[zapcore.Level]string{
+		zap.DebugLevel: colorMagenta,
+		zap.InfoLevel:  colorBlue,
+		zap.WarnLevel:  colorYellow,
+		zap.ErrorLevel: colorRed,
+	}
+)
+
+// textEncoder writes an entry per line as console or logfmt output. Fields added with zap's With are kept
+// in the embedded map and written sorted by key before the fields of the entry.
+type textEncoder struct {
+	*zapcore.MapObjectEncoder
+	console bool
+	color   bool
+}
+
+// getFormat returns LOG_FORMAT, or json if it is unknown.
+func getFormat() string {
+	format := os.Getenv(envLogFormat)
+	if _, err := newEncoder(format, false); err != nil {
+		return "json"
+	}
+	return format
+}
+
+// newEncoder returns the encoder for json, console or logfmt. The console writes colors if color is set and
+// NO_COLOR is not.
+func newEncoder(encoding string, color bool) (zapcore.Encoder, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "json":
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.LevelKey = "level"
+		encoderConfig.TimeKey = "time"
+		encoderConfig.MessageKey = "msg"
+		encoderConfig.EncodeTime = zapcore.RFC3339TimeEncoder
+		encoderConfig.StacktraceKey = ""
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	case "console":
+		return &textEncoder{
+			MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+			console:          true,
+			color:            color && os.Getenv("NO_COLOR") == "",
+		}, nil
+	case "logfmt":
+		return &textEncoder{
+			MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %v, expected json, console or logfmt", encoding)
+	}
+}
+
+// terminals reports whether all outputs are stdout or stderr connected to a terminal.
+func terminals(outputs ...string) bool {
+	for _, output := range outputs {
+		var f *os.File
+		switch output {
+		case "stdout":
+			f = os.Stdout
+		case "stderr":
+			f = os.Stderr
+		default:
+			return false
+		}
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return len(outputs) > 0
+}
+
+func (e *textEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	maps.Copy(clone.Fields, e.Fields)
+	return &textEncoder{
+		MapObjectEncoder: clone,
+		console:          e.console,
+		color:            e.color,
+	}
+}
+
+func (e *textEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf := textPool.Get()
+	if e.console {
+		e.writeConsoleHeader(buf, ent)
+	} else {
+		e.writeLogfmtHeader(buf, ent)
+	}
+	for _, key := range slices.Sorted(maps.Keys(e.Fields)) {
+		e.writeField(buf, key, e.Fields[key])
+	}
+	for _, f := range fields {
+		m := zapcore.NewMapObjectEncoder()
+		f.AddTo(m)
+		for _, key := range slices.Sorted(maps.Keys(m.Fields)) {
+			e.writeField(buf, key, m.Fields[key])
+		}
+	}
+	buf.AppendByte('\n')
+	return buf, nil
+}
+
+func (e *textEncoder) writeConsoleHeader(buf *buffer.Buffer, ent zapcore.Entry) {
+	e.paint(buf, colorGray, ent.Time.Format("15:04:05.000"))
+	buf.AppendByte(' ')
+	e.paint(buf, levelColors[ent.Level], fmt.Sprintf("%-5s", strings.ToUpper(ent.Level.String())))
+	buf.AppendByte(' ')
+	if ent.Caller.Defined {
+		e.paint(buf, colorGray, fmt.Sprintf("%-*s", callerWidth, ent.Caller.TrimmedPath()))
+		buf.AppendByte(' ')
+	}
+	if ent.LoggerName != "" {
+		buf.AppendString("[" + ent.LoggerName + "] ")
+	}
+	buf.AppendString(ent.Message)
+}
+
+func (e *textEncoder) writeLogfmtHeader(buf *buffer.Buffer, ent zapcore.Entry) {
+	buf.AppendString("time=" + ent.Time.Format(time.RFC3339))
+	buf.AppendString(" level=" + ent.Level.String())
+	if ent.LoggerName != "" {
+		buf.AppendString(" logger=" + quote(ent.LoggerName))
+	}
+	if ent.Caller.Defined {
+		buf.AppendString(" caller=" + quote(ent.Caller.TrimmedPath()))
+	}
+	buf.AppendString(" msg=" + quote(ent.Message))
+}
+
+// writeField quotes values as needed. The console writes objects as a field per key, e.g. user.id=42, and arrays
+// as [a b], logfmt writes them as quoted JSON.
+func (e *textEncoder) writeField(buf *buffer.Buffer, key string, value any) {
+	text, isJSON := formatValue(value)
+	if e.console && isJSON {
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.UseNumber()
+		var decoded any
+		if dec.Decode(&decoded) == nil {
+			e.writeConsoleField(buf, key, decoded)
+			return
+		}
+	}
+	e.writeKey(buf, key)
+	buf.AppendString(quote(text))
+}
+
+func (e *textEncoder) writeConsoleField(buf *buffer.Buffer, key string, value any) {
+	if m, ok := value.(map[string]any); ok && len(m) > 0 {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			e.writeConsoleField(buf, key+"."+k, m[k])
+		}
+		return
+	}
+	e.writeKey(buf, key)
+	buf.AppendString(consoleText(value))
+}
+
+func (e *textEncoder) writeKey(buf *buffer.Buffer, key string) {
+	buf.AppendByte(' ')
+	e.paint(buf, colorCyan, quote(key)+"=")
+}
+
+// consoleText formats a value decoded from JSON. Objects in arrays stay JSON.
+func consoleText(value any) string {
+	switch v := value.(type) {
+	case []any:
+		elems := make([]string, 0, len(v))
+		for _, elem := range v {
+			elems = append(elems, consoleText(elem))
+		}
+		return "[" + strings.Join(elems, " ") + "]"
+	case map[string]any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	text, _ := formatValue(value)
+	return quote(text)
+}
+
+func (e *textEncoder) paint(buf *buffer.Buffer, color, s string) {
+	if !e.color || color == "" {
+		buf.AppendString(s)
+		return
+	}
+	buf.AppendString(color)
+	buf.AppendString(s)
+	buf.AppendString(colorReset)
+}
+
+// formatValue formats values of the MapObjectEncoder as text. Objects and arrays are formatted as JSON, in
+// which case isJSON is set.
+func formatValue(value any) (text string, isJSON bool) {
+	switch v := value.(type) {
+	case string:
+		return v, false
+	case []byte:
+		return string(v), false
+	case time.Time:
+		return v.Format(time.RFC3339), false
+	case time.Duration:
+		return v.String(), false
+	case error:
+		return v.Error(), false
+	case fmt.Stringer:
+		return v.String(), false
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+		return fmt.Sprint(v), false
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value), false
+	}
+	return string(data), true
+}
+
+// quote quotes s if it is empty or contains spaces, quotes, equal signs or control characters.
+func quote(s string) string {
+	if s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return r == '"' || r == '=' || r == '\\' || unicode.IsSpace(r) || !unicode.IsPrint(r)
+	}) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
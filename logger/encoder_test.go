@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestConsoleEncodingWritesAlignedLines(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	l, out := newTestLogger(t, Options{Encoding: "console"})
+
+	l.Named("db").Info("order created", Field{Key: "orderId", Value: 42}, Field{Key: "customer", Value: "ACME Inc."},
+		Field{Key: "items", Value: []string{"a", "b c"}})
+	l.Warn(warnMsg)
+
+	assert.Regexp(t, `^\d{2}:\d{2}:\d{2}\.\d{3} INFO  logger/encoder_test\.go:\d+ +\[db\] order created orderId=42 customer="ACME Inc\." items=\[a "b c"\]
+\d{2}:\d{2}:\d{2}\.\d{3} WARN  logger/encoder_test\.go:\d+ +a warn message
+$`, out.String())
+}
+
+func TestConsoleEncodingWritesObjectsAsFields(t *testing.T) {
+	l, out := newTestLogger(t, Options{Encoding: "console", DisableCaller: true})
+
+	l.Info("order created", Field{Key: "customer", Value: map[string]any{
+		"name":    "ACME Inc.",
+		"id":      int64(9007199254740993),
+		"address": map[string]any{"city": "Berlin"},
+		"tags":    []any{map[string]any{"k": "v"}},
+	}})
+
+	assert.Contains(t, out.String(), ` order created customer.address.city=Berlin customer.id=9007199254740993 customer.name="ACME Inc." customer.tags=[{"k":"v"}]`+"\n")
+}
+
+func TestConsoleEncodingColorsLevels(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	enc, err := newEncoder("Console", true)
+	assert.Nil(t, err)
+
+	buf, err := enc.EncodeEntry(zapcore.Entry{Level: zap.ErrorLevel, Message: errorMsg}, []zapcore.Field{zap.NamedError("error", errors.New(newErrorMsg))})
+
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), colorRed+"ERROR"+colorReset+" "+errorMsg+" "+colorCyan+"error="+colorReset+`"`+newErrorMsg+`"`)
+}
+
+func TestConsoleEncodingWithoutTerminalHasNoColors(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	l, out := newTestLogger(t, Options{Encoding: "console"})
+
+	l.Error(errorMsg, errors.New(newErrorMsg))
+
+	assert.NotContains(t, out.String(), "\x1b[")
+	assert.False(t, terminals(filepath.Join(t.TempDir(), "app.log")))
+	assert.False(t, terminals())
+}
+
+func TestLogfmtEncodingWritesKeyValues(t *testing.T) {
+	l, out := newTestLogger(t, Options{Encoding: "logfmt"})
+
+	l.Named("db").Info("order created", Field{Key: "orderId", Value: 42}, Field{Key: "customer", Value: "ACME Inc."},
+		Field{Key: "items", Value: []string{"a", "b"}}, Field{Key: "took", Value: 1500 * time.Millisecond})
+
+	assert.Regexp(t, `^time=\S+ level=info logger=db caller=logger/encoder_test\.go:\d+ msg="order created" orderId=42 customer="ACME Inc\." items="\[\\"a\\",\\"b\\"\]" took=1\.5s
+$`, out.String())
+}
+
+func TestLogFormatFromEnvironment(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "logfmt")
+	initLogger(true, FileOptions{})
+	t.Cleanup(func() {
+		t.Setenv("LOG_FORMAT", "")
+		initLogger(true, FileOptions{})
+	})
+
+	Info(infoMsg)
+
+	assert.Regexp(t, `^time=\S+ level=info caller=\S+ msg="an info message"`, sink.String())
+}
+
+func TestLogFormatFromEnvironmentFallsBackToJSON(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "xml")
+	initLogger(true, FileOptions{})
+
+	Info(infoMsg)
+
+	assert.EqualValues(t, infoMsg, extractLog()["msg"])
+}
+
+func TestTextEncoderWritesContextFieldsFirst(t *testing.T) {
+	enc, err := newEncoder("logfmt", false)
+	assert.Nil(t, err)
+	withFields := enc.Clone()
+	withFields.AddString("b", "2")
+	withFields.AddBool("a", true)
+
+	buf, err := withFields.EncodeEntry(zapcore.Entry{Message: "m", Time: time.Date(2026, 10, 19, 15, 4, 5, 0, time.UTC)},
+		[]zapcore.Field{zap.Int("c", 3)})
+	assert.Nil(t, err)
+
+	assert.EqualValues(t, "time=2026-10-19T15:04:05Z level=info msg=m a=true b=2 c=3\n", buf.String())
+	buf, _ = enc.EncodeEntry(zapcore.Entry{Message: "m"}, nil)
+	assert.NotContains(t, buf.String(), "a=true")
+}
+
+func TestNewEncoderRejectsUnknownEncoding(t *testing.T) {
+	_, err := newEncoder("xml", false)
+
+	assert.EqualValues(t, "unknown encoding xml, expected json, console or logfmt", err.Error())
+}
+
+func TestQuote(t *testing.T) {
+	for s, quoted := range map[string]string{
+		"":          `""`,
+		"plain":     "plain",
+		"two words": `"two words"`,
+		"a=b":       `"a=b"`,
+		`say "hi"`:  `"say \"hi\""`,
+		"line\n":    `"line\n"`,
+	} {
+		assert.EqualValues(t, quoted, quote(s), s)
+	}
+}
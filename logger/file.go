@@ -0,0 +1,250 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Usage:
+//
+//	logger.InitFile(logger.FileOptions{Filename: "/var/log/app/app.log", MaxSize: 20, Rotation: logger.RotateDaily})
+//
+// Options left empty are read from the environment, then default to the values documented on FileOptions:
+//
+//	LOG_FILE_MAX_SIZE=20 (megabytes)
+//	LOG_FILE_MAX_AGE=3 (days)
+//	LOG_FILE_MAX_BACKUPS=3
+//	LOG_FILE_COMPRESS=false
+//	LOG_FILE_LOCAL_TIME=true
+//	LOG_FILE_ROTATION=daily (or hourly, size)
+//	LOG_FILE_ROTATE_ON_SIGHUP=true
+//
+// Rotated files are named after the file and the rotation time, e.g. app-2026-10-19T00-00-00.000.log.gz.
+
+const (
+	envLogFileMaxSize        = "LOG_FILE_MAX_SIZE"
+	envLogFileMaxAge         = "LOG_FILE_MAX_AGE"
+	envLogFileMaxBackups     = "LOG_FILE_MAX_BACKUPS"
+	envLogFileCompress       = "LOG_FILE_COMPRESS"
+	envLogFileLocalTime      = "LOG_FILE_LOCAL_TIME"
+	envLogFileRotation       = "LOG_FILE_ROTATION"
+	envLogFileRotateOnHangup = "LOG_FILE_ROTATE_ON_SIGHUP"
+)
+
+// Rotation rotates the log file at fixed times, in addition to rotating it by size.
+type Rotation int
+
+const (
+	RotateBySize Rotation = iota
+	RotateHourly
+	RotateDaily
+)
+
+// FileOptions configures the log file and its rotation.
+type FileOptions struct {
+	Filename string
+	// MaxSize is the size in megabytes at which the file is rotated, defaults to 100.
+	MaxSize int
+	// MaxAge is the number of days rotated files are kept, defaults to 7. Negative values keep them forever.
+	MaxAge int
+	// MaxBackups is the number of rotated files kept, defaults to 7. Negative values keep all of them.
+	MaxBackups int
+	// Compress gzips rotated files, defaults to true.
+	Compress *bool
+	// LocalTime uses local time instead of UTC for the names of rotated files and for time-based rotation.
+	LocalTime *bool
+	Rotation  Rotation
+	// RotateOnSignal rotates the file when the process receives SIGHUP. It has no effect on Windows.
+	RotateOnSignal *bool
+}
+
+// logFile is the open log file with its rotation goroutines.
+type logFile struct {
+	*lumberjack.Logger
+	mu     sync.Mutex
+	closed bool
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// InitFile initializes the logger like Init with rotation options for the log file.
+func InitFile(opts FileOptions) {
+	initLogger(false, opts)
+}
+
+// Rotate closes the log file, renames it with the current time and opens a new file. It does nothing if the
+// logger does not write to a file.
+func Rotate() error {
+	return root.Rotate()
+}
+
+func (l *Logger) Rotate() error {
+	return l.instance().out.Load().file.rotate()
+}
+
+func (f *logFile) Sync() error {
+	return nil
+}
+
+// resolve fills empty options from the environment and the defaults.
+func (opts FileOptions) resolve() FileOptions {
+	if opts.MaxSize == 0 {
+		opts.MaxSize = envInt(envLogFileMaxSize, 100)
+	}
+	if opts.MaxAge == 0 {
+		opts.MaxAge = envInt(envLogFileMaxAge, 7)
+	}
+	if opts.MaxBackups == 0 {
+		opts.MaxBackups = envInt(envLogFileMaxBackups, 7)
+	}
+	if opts.Compress == nil {
+		opts.Compress = new(envBool(envLogFileCompress, true))
+	}
+	if opts.LocalTime == nil {
+		opts.LocalTime = new(envBool(envLogFileLocalTime, false))
+	}
+	if opts.Rotation == RotateBySize {
+		opts.Rotation, _ = parseRotation(os.Getenv(envLogFileRotation))
+	}
+	if opts.RotateOnSignal == nil {
+		opts.RotateOnSignal = new(envBool(envLogFileRotateOnHangup, false))
+	}
+	return opts
+}
+
+func parseRotation(value string) (Rotation, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "size":
+		return RotateBySize, nil
+	case "hourly":
+		return RotateHourly, nil
+	case "daily":
+		return RotateDaily, nil
+	default:
+		return RotateBySize, fmt.Errorf("unknown rotation %v, expected size, hourly or daily", value)
+	}
+}
+
+func envInt(key string, fallback int) int {
+	if value, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key))); err == nil && value != 0 {
+		return value
+	}
+	return fallback
+}
+
+func envBool(key string, fallback bool) bool {
+	if value, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(key))); err == nil {
+		return value
+	}
+	return fallback
+}
+
+// openFile opens the log file and starts its time and signal based rotation.
+func openFile(opts FileOptions) *logFile {
+	opts = opts.resolve()
+	f := &logFile{
+		Logger: &lumberjack.Logger{
+			Filename:   opts.Filename,
+			MaxSize:    opts.MaxSize,
+			MaxAge:     max(opts.MaxAge, 0),
+			MaxBackups: max(opts.MaxBackups, 0),
+			LocalTime:  *opts.LocalTime,
+			Compress:   *opts.Compress,
+		},
+		stop: make(chan struct{}),
+	}
+	if opts.Rotation != RotateBySize {
+		f.wg.Go(func() {
+			f.rotateAt(opts.Rotation, *opts.LocalTime)
+		})
+	}
+	if *opts.RotateOnSignal {
+		signals := make(chan os.Signal, 1)
+		notifyRotate(signals)
+		f.wg.Go(func() {
+			defer signal.Stop(signals)
+			for {
+				select {
+				case <-signals:
+					_ = f.rotate()
+				case <-f.stop:
+					return
+				}
+			}
+		})
+	}
+	return f
+}
+
+func (f *logFile) rotateAt(rotation Rotation, local bool) {
+	for {
+		timer := time.NewTimer(time.Until(nextRotation(time.Now(), rotation, local)))
+		select {
+		case <-timer.C:
+			_ = f.rotate()
+		case <-f.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// nextRotation returns the start of the hour or day after now, in UTC unless local is set.
+func nextRotation(now time.Time, rotation Rotation, local bool) time.Time {
+	if !local {
+		now = now.UTC()
+	}
+	y, m, d := now.Date()
+	if rotation == RotateHourly {
+		return time.Date(y, m, d, now.Hour()+1, 0, 0, 0, now.Location())
+	}
+	return time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+}
+
+// Write writes to the file unless it was closed. Writers that loaded the previous output while the logger was
+// reconfigured would otherwise reopen the file and leak its descriptor.
+func (f *logFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return len(p), nil
+	}
+	return f.Logger.Write(p)
+}
+
+// rotate rotates the file unless it was closed, as lumberjack would open it again.
+func (f *logFile) rotate() error {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil
+	}
+	return f.Rotate()
+}
+
+// close stops the rotation and closes the file.
+func (f *logFile) close() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return
+	}
+	f.closed = true
+	close(f.stop)
+	f.mu.Unlock()
+	f.wg.Wait()
+	_ = f.Close()
+}
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func initTestFile(t *testing.T, opts FileOptions) string {
+	t.Helper()
+	opts.Filename = filepath.Join(t.TempDir(), "app.log")
+	initLogger(true, opts)
+	t.Cleanup(func() {
+		initLogger(true, FileOptions{})
+	})
+	return opts.Filename
+}
+
+func backups(t *testing.T, logFile string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(logFile), "app-*"))
+	assert.Nil(t, err)
+	return files
+}
+
+func TestFileOptionsDefaults(t *testing.T) {
+	opts := FileOptions{}.resolve()
+
+	assert.EqualValues(t, 100, opts.MaxSize)
+	assert.EqualValues(t, 7, opts.MaxAge)
+	assert.EqualValues(t, 7, opts.MaxBackups)
+	assert.True(t, *opts.Compress)
+	assert.False(t, *opts.LocalTime)
+	assert.EqualValues(t, RotateBySize, opts.Rotation)
+	assert.False(t, *opts.RotateOnSignal)
+}
+
+func TestFileOptionsFromEnv(t *testing.T) {
+	t.Setenv("LOG_FILE_MAX_SIZE", "20")
+	t.Setenv("LOG_FILE_MAX_AGE", "3")
+	t.Setenv("LOG_FILE_MAX_BACKUPS", "-1")
+	t.Setenv("LOG_FILE_COMPRESS", "false")
+	t.Setenv("LOG_FILE_LOCAL_TIME", "true")
+	t.Setenv("LOG_FILE_ROTATION", "Daily")
+	t.Setenv("LOG_FILE_ROTATE_ON_SIGHUP", "true")
+
+	opts := FileOptions{}.resolve()
+
+	assert.EqualValues(t, 20, opts.MaxSize)
+	assert.EqualValues(t, 3, opts.MaxAge)
+	assert.EqualValues(t, -1, opts.MaxBackups)
+	assert.False(t, *opts.Compress)
+	assert.True(t, *opts.LocalTime)
+	assert.EqualValues(t, RotateDaily, opts.Rotation)
+	assert.True(t, *opts.RotateOnSignal)
+}
+
+func TestFileOptionsBeatEnv(t *testing.T) {
+	t.Setenv("LOG_FILE_MAX_SIZE", "20")
+	t.Setenv("LOG_FILE_COMPRESS", "false")
+	t.Setenv("LOG_FILE_ROTATION", "daily")
+
+	opts := FileOptions{MaxSize: 5, Compress: new(true), Rotation: RotateHourly}.resolve()
+
+	assert.EqualValues(t, 5, opts.MaxSize)
+	assert.True(t, *opts.Compress)
+	assert.EqualValues(t, RotateHourly, opts.Rotation)
+}
+
+func TestFileOptionsIgnoreInvalidEnv(t *testing.T) {
+	t.Setenv("LOG_FILE_MAX_SIZE", "big")
+	t.Setenv("LOG_FILE_COMPRESS", "maybe")
+	t.Setenv("LOG_FILE_ROTATION", "weekly")
+
+	opts := FileOptions{}.resolve()
+
+	assert.EqualValues(t, 100, opts.MaxSize)
+	assert.True(t, *opts.Compress)
+	assert.EqualValues(t, RotateBySize, opts.Rotation)
+}
+
+func TestParseRotation(t *testing.T) {
+	for value, rotation := range map[string]Rotation{"": RotateBySize, "size": RotateBySize, "hourly": RotateHourly, " DAILY ": RotateDaily} {
+		r, err := parseRotation(value)
+		assert.Nil(t, err)
+		assert.EqualValues(t, rotation, r, value)
+	}
+	_, err := parseRotation("weekly")
+	assert.EqualValues(t, "unknown rotation weekly, expected size, hourly or daily", err.Error())
+}
+
+func TestNextRotation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.Nil(t, err)
+	now := time.Date(2026, 10, 19, 23, 30, 15, 0, berlin)
+
+	assert.EqualValues(t, time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC), nextRotation(now, RotateHourly, false))
+	assert.EqualValues(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), nextRotation(now, RotateDaily, false))
+	assert.EqualValues(t, time.Date(2026, 10, 20, 0, 0, 0, 0, berlin), nextRotation(now, RotateHourly, true))
+	assert.EqualValues(t, time.Date(2026, 10, 20, 0, 0, 0, 0, berlin), nextRotation(now, RotateDaily, true))
+}
+
+func TestRotate(t *testing.T) {
+	logFile := initTestFile(t, FileOptions{Compress: new(false)})
+	Info("before rotation")
+
+	assert.Nil(t, Rotate())
+	Info("after rotation")
+
+	files := backups(t, logFile)
+	assert.Len(t, files, 1)
+	old, err := os.ReadFile(files[0])
+	assert.Nil(t, err)
+	assert.Contains(t, string(old), "before rotation")
+	current, err := os.ReadFile(logFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(current), "after rotation")
+	assert.NotContains(t, string(current), "before rotation")
+}
+
+func TestRotateCompressesBackups(t *testing.T) {
+	logFile := initTestFile(t, FileOptions{})
+	Info("before rotation")
+
+	assert.Nil(t, Rotate())
+
+	assert.Eventually(t, func() bool {
+		files := backups(t, logFile)
+		return len(files) == 1 && filepath.Ext(files[0]) == ".gz"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRotateWithoutFile(t *testing.T) {
+	initLogger(true, FileOptions{})
+
+	assert.Nil(t, Rotate())
+}
+
+func TestInitClosesPreviousFile(t *testing.T) {
+	initTestFile(t, FileOptions{Rotation: RotateHourly, RotateOnSignal: new(true)})
+	f := std.out.Load().file
+
+	initLogger(true, FileOptions{})
+
+	assert.Nil(t, std.out.Load().file)
+	select {
+	case <-f.stop:
+	default:
+		assert.Fail(t, "rotation goroutines not stopped")
+	}
+}
+
+func TestClosedFileDropsWrites(t *testing.T) {
+	logFile := initTestFile(t, FileOptions{})
+	Info("before close")
+	f := std.out.Load().file
+	initLogger(true, FileOptions{})
+	assert.Nil(t, os.Remove(logFile))
+
+	n, err := f.Write([]byte("late write\n"))
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, 11, n)
+	assert.NoFileExists(t, logFile)
+}
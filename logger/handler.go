@@ -0,0 +1,396 @@
+package logger
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johannes-kuhfuss/services_utils/api_error"
+)
+
+// Usage:
+//
+//	mux.Handle("/logs/", http.StripPrefix("/logs", logger.Handler(logger.HandlerOptions{Token: os.Getenv("LOG_TOKEN")})))
+//	mux.Handle("/loglevel", logger.LevelHandler(logger.HandlerOptions{Token: os.Getenv("LOG_TOKEN")}))
+//
+// GET /logs returns a page of the log list as JSON, filtered by the query parameters level, component, minLevel, from, to
+// (RFC3339), q (substring), regex, field (key:value), cursor, limit and order (asc or desc).
+// GET /logs/stream tails new entries matching the same filters, as Server-Sent Events if the client accepts
+// text/event-stream and as newline delimited JSON otherwise. DELETE /logs clears the log list.
+
+type HandlerOptions struct {
+	// Username and Password enable basic authentication.
+	Username string
+	Password string
+	// Token enables bearer token authentication. If both are set, either is accepted.
+	Token string
+	// StreamBuffer is the number of entries buffered per stream before entries are dropped, defaults to 100.
+	StreamBuffer int
+	// Logger is the logger whose log list and levels are served, defaults to the package logger.
+	Logger *Logger
+}
+
+type levelRequest struct {
+	Level     string `json:"level"`
+	Component string `json:"component,omitempty"`
+	// RevertAfter is a duration such as "15m" after which the previous level is restored.
+	RevertAfter string `json:"revertAfter,omitempty"`
+}
+
+type componentLevel struct {
+	Level    string     `json:"level"`
+	RevertAt *time.Time `json:"revertAt,omitempty"`
+}
+
+type levelResponse struct {
+	Level      string                    `json:"level"`
+	RevertAt   *time.Time                `json:"revertAt,omitempty"`
+	Components map[string]componentLevel `json:"components"`
+}
+
+type pageResponse struct {
+	Entries []LogEntry `json:"entries"`
+	Next    uint64     `json:"next,omitempty"`
+}
+
+// Handler serves the log list, see the usage above.
+func Handler(opts HandlerOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !opts.authorized(req) {
+			opts.challenge(w)
+			return
+		}
+
+		l := opts.logger()
+		stream := strings.Trim(req.URL.Path, "/") == "stream"
+		switch {
+		case req.Method == http.MethodDelete && !stream:
+			l.ClearLogList()
+			w.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodGet || req.Method == http.MethodHead:
+			q, err := ParseQuery(req.URL.Query())
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			if stream {
+				streamLogList(w, req, l, q, opts.StreamBuffer)
+				return
+			}
+			page := l.QueryLogList(q)
+			for i := range page.Entries {
+				page.Entries[i] = encodableEntry(page.Entries[i])
+			}
+			writeJSON(w, req, pageResponse{
+				Entries: page.Entries,
+				Next:    page.Next,
+			})
+		default:
+			if stream {
+				w.Header().Set("Allow", "GET, HEAD")
+			} else {
+				w.Header().Set("Allow", "GET, HEAD, DELETE")
+			}
+			writeError(w, api_error.NewError("method not allowed", http.StatusMethodNotAllowed, nil))
+		}
+	})
+}
+
+// LevelHandler reads and changes the log levels. GET returns the global and component levels. PUT or POST with
+// {"level": "debug", "component": "db", "revertAfter": "15m"} sets a level, where component and revertAfter are
+// optional. DELETE ?component=db removes a component override.
+func LevelHandler(opts HandlerOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !opts.authorized(req) {
+			opts.challenge(w)
+			return
+		}
+
+		l := opts.logger()
+		switch req.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			var body levelRequest
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				writeError(w, api_error.NewBadRequestError("invalid level request"))
+				return
+			}
+			var revertAfter time.Duration
+			if body.RevertAfter != "" {
+				var err error
+				if revertAfter, err = time.ParseDuration(body.RevertAfter); err != nil || revertAfter < 0 {
+					writeError(w, api_error.NewBadRequestError(fmt.Sprintf("invalid revertAfter %v", body.RevertAfter)))
+					return
+				}
+			}
+			if err := l.setLevelFor(strings.TrimSpace(body.Component), body.Level, revertAfter); err != nil {
+				writeError(w, api_error.NewBadRequestError(err.Error()))
+				return
+			}
+		case http.MethodDelete:
+			component := strings.TrimSpace(req.URL.Query().Get("component"))
+			if component == "" {
+				writeError(w, api_error.NewBadRequestError("component must not be empty"))
+				return
+			}
+			l.resetLevelFor(component)
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT, POST, DELETE")
+			writeError(w, api_error.NewError("method not allowed", http.StatusMethodNotAllowed, nil))
+			return
+		}
+
+		resp := levelResponse{
+			Level:      l.GetLevel(),
+			RevertAt:   l.revertAt(""),
+			Components: make(map[string]componentLevel),
+		}
+		for component, lvl := range l.ComponentLevels() {
+			resp.Components[component] = componentLevel{
+				Level:    lvl,
+				RevertAt: l.revertAt(component),
+			}
+		}
+		writeJSON(w, req, resp)
+	})
+}
+
+func (opts HandlerOptions) logger() *Logger {
+	if opts.Logger != nil {
+		return opts.Logger
+	}
+	return root
+}
+
+func (opts HandlerOptions) challenge(w http.ResponseWriter) {
+	if opts.Username != "" {
+		w.Header().Add("WWW-Authenticate", `Basic realm="logs"`)
+	}
+	if opts.Token != "" {
+		w.Header().Add("WWW-Authenticate", `Bearer realm="logs"`)
+	}
+	writeError(w, api_error.NewUnauthenticatedError("not authorized to access the logger"))
+}
+
+func (opts HandlerOptions) authorized(req *http.Request) bool {
+	if opts.Username == "" && opts.Token == "" {
+		return true
+	}
+	if opts.Username != "" {
+		if user, password, ok := req.BasicAuth(); ok && equal(user, opts.Username) && equal(password, opts.Password) {
+			return true
+		}
+	}
+	if opts.Token != "" {
+		auth := req.Header.Get("Authorization")
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok && equal(token, opts.Token) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// ParseQuery reads a Query from URL query parameters as described in the Handler usage.
+func ParseQuery(values url.Values) (Query, api_error.ApiErr) {
+	q := Query{
+		Filter: Filter{
+			MinLevel: values.Get("minLevel"),
+			Contains: values.Get("q"),
+		},
+	}
+	q.Levels = splitValues(values["level"])
+	q.Components = splitValues(values["component"])
+	var err error
+	if q.From, err = parseTime(values.Get("from")); err != nil {
+		return Query{}, api_error.NewBadRequestError(fmt.Sprintf("invalid from time %v", values.Get("from")))
+	}
+	if q.To, err = parseTime(values.Get("to")); err != nil {
+		return Query{}, api_error.NewBadRequestError(fmt.Sprintf("invalid to time %v", values.Get("to")))
+	}
+	if pattern := values.Get("regex"); pattern != "" {
+		if q.Pattern, err = regexp.Compile(pattern); err != nil {
+			return Query{}, api_error.NewBadRequestError(fmt.Sprintf("invalid regex %v", pattern))
+		}
+	}
+	for _, field := range values["field"] {
+		key, value, ok := strings.Cut(field, ":")
+		if !ok || key == "" {
+			return Query{}, api_error.NewBadRequestError(fmt.Sprintf("invalid field filter %v, expected key:value", field))
+		}
+		if q.Fields == nil {
+			q.Fields = make(map[string]string)
+		}
+		q.Fields[key] = value
+	}
+	if cursor := values.Get("cursor"); cursor != "" {
+		if q.Cursor, err = strconv.ParseUint(cursor, 10, 64); err != nil {
+			return Query{}, api_error.NewBadRequestError(fmt.Sprintf("invalid cursor %v", cursor))
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit < 0 {
+			return Query{}, api_error.NewBadRequestError(fmt.Sprintf("invalid limit %v", limit))
+		}
+	}
+	switch strings.ToLower(values.Get("order")) {
+	case "", "asc":
+	case "desc":
+		q.Descending = true
+	default:
+		return Query{}, api_error.NewBadRequestError(fmt.Sprintf("invalid order %v, expected asc or desc", values.Get("order")))
+	}
+	return q, nil
+}
+
+// splitValues returns the non-empty items of repeated and comma separated query parameters.
+func splitValues(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// streamLogList writes new entries until the client disconnects. Streams start after the newest entry,
+// or after the cursor or Last-Event-ID if given, in which case the missed entries are sent first.
+func streamLogList(w http.ResponseWriter, req *http.Request, l *Logger, q Query, bufferSize int) {
+	sse := strings.Contains(req.Header.Get("Accept"), "text/event-stream")
+	if lastID := req.Header.Get("Last-Event-ID"); lastID != "" {
+		if id, err := strconv.ParseUint(lastID, 10, 64); err == nil {
+			q.Cursor = id
+		}
+	}
+	q.Descending = false
+
+	if sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil || req.Method == http.MethodHead {
+		return
+	}
+
+	// Subscribe before reading the missed entries, so that no entry falls in between. Entries read from the
+	// log list may arrive from the subscription as well and are skipped there.
+	if q.Cursor == 0 {
+		q.Cursor = l.instance().logListSeq.Load()
+	}
+	start := q.Cursor
+	entries, cancel := l.SubscribeWithOptions(q.Filter, SubscribeOptions{
+		Name:       "http stream " + req.RemoteAddr,
+		BufferSize: bufferSize,
+	})
+	defer cancel()
+	sent := make(map[uint64]struct{})
+	for {
+		page := l.QueryLogList(q)
+		for _, e := range page.Entries {
+			if err := writeEntry(w, e, sse); err != nil {
+				return
+			}
+			sent[e.Seq] = struct{}{}
+			q.Cursor = e.Seq
+		}
+		if page.Next == 0 {
+			break
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case e := <-entries:
+			if _, ok := sent[e.Seq]; ok || e.Seq <= start {
+				continue
+			}
+			if err := writeEntry(w, e, sse); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// encodableEntry replaces field values that json cannot encode, such as channels or NaN, with their fmt
+// representation, so that a single field does not fail the whole response.
+func encodableEntry(e LogEntry) LogEntry {
+	var fields []Field
+	for i, field := range e.Fields {
+		if _, err := json.Marshal(field.Value); err == nil {
+			continue
+		}
+		if fields == nil {
+			fields = slices.Clone(e.Fields)
+		}
+		fields[i].Value = fmt.Sprint(field.Value)
+	}
+	if fields != nil {
+		e.Fields = fields
+	}
+	return e
+}
+
+func writeEntry(w http.ResponseWriter, e LogEntry, sse bool) error {
+	data, err := json.Marshal(encodableEntry(e))
+	if err != nil {
+		return err
+	}
+	if sse {
+		_, err = fmt.Fprintf(w, "id: %d\nevent: log\ndata: %s\n\n", e.Seq, data)
+	} else {
+		_, err = fmt.Fprintf(w, "%s\n", data)
+	}
+	return err
+}
+
+func writeJSON(w http.ResponseWriter, req *http.Request, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		writeError(w, api_error.NewInternalServerError("could not encode log list", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if req.Method != http.MethodHead {
+		_, _ = w.Write(data)
+	}
+}
+
+func writeError(w http.ResponseWriter, err api_error.ApiErr) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.StatusCode())
+	_ = json.NewEncoder(w).Encode(err)
+}
@@ -0,0 +1,207 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveLogs(h http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	http.StripPrefix("/logs", h).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerReturnsFilteredPages(t *testing.T) {
+	fillLogList()
+	h := Handler(HandlerOptions{})
+
+	rec := serveLogs(h, http.MethodGet, "/logs?level=info&field=orderId:42&order=desc&limit=1", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, "application/json", rec.Header().Get("Content-Type"))
+	var page pageResponse
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.EqualValues(t, []string{"order shipped"}, messages(page.Entries))
+	assert.NotZero(t, page.Next)
+
+	rec = serveLogs(h, http.MethodGet, "/logs?level=info&field=orderId:42&order=desc&limit=1&cursor="+strconv.FormatUint(page.Next, 10), nil)
+
+	page = pageResponse{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.EqualValues(t, []string{"order created"}, messages(page.Entries))
+	assert.Zero(t, page.Next)
+}
+
+func TestHandlerStringifiesFieldsThatCannotBeEncoded(t *testing.T) {
+	ClearLogList()
+	addToLogList("Info", "odd field", Field{Key: "ratio", Value: math.NaN()}, Field{Key: "orderId", Value: 42})
+
+	rec := serveLogs(Handler(HandlerOptions{}), http.MethodGet, "/logs", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	var page pageResponse
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.EqualValues(t, []Field{{Key: "ratio", Value: "NaN"}, {Key: "orderId", Value: float64(42)}}, page.Entries[0].Fields)
+}
+
+func TestHandlerHeadReturnsNoBody(t *testing.T) {
+	fillLogList()
+
+	rec := serveLogs(Handler(HandlerOptions{}), http.MethodHead, "/logs", nil)
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestHandlerInvalidQueryReturnsBadRequest(t *testing.T) {
+	for _, target := range []string{
+		"/logs?from=yesterday",
+		"/logs?to=tomorrow",
+		"/logs?regex=(",
+		"/logs?field=orderId",
+		"/logs?cursor=-1",
+		"/logs?limit=many",
+		"/logs?order=random",
+	} {
+		rec := serveLogs(Handler(HandlerOptions{}), http.MethodGet, target, nil)
+
+		assert.EqualValues(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	values, _ := url.ParseQuery("level=info,warn&level=error&minLevel=warn&from=2024-05-01T00:00:00Z&to=2024-05-02T00:00:00Z&q=order&regex=^o&field=tenantId:t1&cursor=5&limit=10&order=DESC")
+
+	q, err := ParseQuery(values)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"info", "warn", "error"}, q.Levels)
+	assert.EqualValues(t, "warn", q.MinLevel)
+	assert.EqualValues(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), q.From)
+	assert.EqualValues(t, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), q.To)
+	assert.EqualValues(t, "order", q.Contains)
+	assert.EqualValues(t, "^o", q.Pattern.String())
+	assert.EqualValues(t, map[string]string{"tenantId": "t1"}, q.Fields)
+	assert.EqualValues(t, 5, q.Cursor)
+	assert.EqualValues(t, 10, q.Limit)
+	assert.True(t, q.Descending)
+}
+
+func TestHandlerDeleteClearsLogList(t *testing.T) {
+	fillLogList()
+
+	rec := serveLogs(Handler(HandlerOptions{}), http.MethodDelete, "/logs", nil)
+
+	assert.EqualValues(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, GetLogList())
+}
+
+func TestHandlerOtherMethodsReturnMethodNotAllowed(t *testing.T) {
+	rec := serveLogs(Handler(HandlerOptions{}), http.MethodPost, "/logs", nil)
+	stream := serveLogs(Handler(HandlerOptions{}), http.MethodDelete, "/logs/stream", nil)
+
+	assert.EqualValues(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.EqualValues(t, "GET, HEAD, DELETE", rec.Header().Get("Allow"))
+	assert.EqualValues(t, http.StatusMethodNotAllowed, stream.Code)
+	assert.EqualValues(t, "GET, HEAD", stream.Header().Get("Allow"))
+}
+
+func TestHandlerBasicAuth(t *testing.T) {
+	fillLogList()
+	h := Handler(HandlerOptions{Username: "admin", Password: "secret"})
+	req := httptest.NewRequest(http.MethodGet, "/logs", nil)
+	req.SetBasicAuth("admin", "secret")
+
+	missing := serveLogs(h, http.MethodGet, "/logs", nil)
+	wrong := serveLogs(h, http.MethodGet, "/logs", http.Header{"Authorization": {"Basic YWRtaW46d3Jvbmc="}})
+	ok := serveLogs(h, http.MethodGet, "/logs", http.Header{"Authorization": req.Header["Authorization"]})
+
+	assert.EqualValues(t, http.StatusUnauthorized, missing.Code)
+	assert.EqualValues(t, `Basic realm="logs"`, missing.Header().Get("WWW-Authenticate"))
+	assert.EqualValues(t, http.StatusUnauthorized, wrong.Code)
+	assert.EqualValues(t, http.StatusOK, ok.Code)
+	assert.EqualValues(t, 5, len(GetLogList()))
+}
+
+func TestHandlerTokenAuth(t *testing.T) {
+	fillLogList()
+	h := Handler(HandlerOptions{Token: "t0ken"})
+
+	missing := serveLogs(h, http.MethodDelete, "/logs", nil)
+	wrong := serveLogs(h, http.MethodDelete, "/logs", http.Header{"Authorization": {"Bearer wrong"}})
+	ok := serveLogs(h, http.MethodDelete, "/logs", http.Header{"Authorization": {"Bearer t0ken"}})
+
+	assert.EqualValues(t, http.StatusUnauthorized, missing.Code)
+	assert.EqualValues(t, `Bearer realm="logs"`, missing.Header().Get("WWW-Authenticate"))
+	assert.EqualValues(t, http.StatusUnauthorized, wrong.Code)
+	assert.EqualValues(t, http.StatusNoContent, ok.Code)
+}
+
+func openStream(t *testing.T, target string, header http.Header) (*bufio.Reader, *http.Response) {
+	srv := httptest.NewServer(http.StripPrefix("/logs", Handler(HandlerOptions{})))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+target, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		resp.Body.Close()
+	})
+	return bufio.NewReader(resp.Body), resp
+}
+
+func TestHandlerStreamsNewEntriesAsNDJSON(t *testing.T) {
+	fillLogList()
+	r, resp := openStream(t, "/logs/stream?minLevel=warn", nil)
+
+	addToLogList("Info", "skipped")
+	addToLogList("Error", "streamed")
+
+	assert.EqualValues(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	line, err := r.ReadString('\n')
+	assert.Nil(t, err)
+	var e LogEntry
+	assert.Nil(t, json.Unmarshal([]byte(line), &e))
+	assert.EqualValues(t, "streamed", e.LogMessage)
+}
+
+func TestHandlerStreamsServerSentEvents(t *testing.T) {
+	fillLogList()
+	last := GetLogList()[3].Seq
+	r, resp := openStream(t, "/logs/stream", http.Header{
+		"Accept":        {"text/event-stream"},
+		"Last-Event-Id": {strconv.FormatUint(last, 10)},
+	})
+
+	assert.EqualValues(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	event := make([]string, 0, 3)
+	for len(event) < 3 {
+		line, err := r.ReadString('\n')
+		assert.Nil(t, err)
+		event = append(event, strings.TrimSuffix(line, "\n"))
+	}
+	assert.EqualValues(t, "id: "+strconv.FormatUint(last+1, 10), event[0])
+	assert.EqualValues(t, "event: log", event[1])
+	assert.Contains(t, event[2], `"LogMessage":"order shipped"`)
+}
@@ -0,0 +1,268 @@
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Usage:
+//
+//	logger.SetLevel("debug")
+//	logger.SetComponentLevel("db", "debug") // only logger.Named("db") and its children, e.g. "db.pool"
+//
+// The level starts at LOG_LEVEL and can be changed at runtime, also through LevelHandler. Component
+// levels override the global level for named loggers.
+
+// levelCore filters entries by the global or component level. The wrapped core accepts all levels.
+type levelCore struct {
+	zapcore.Core
+	inst *instance
+}
+
+func (c levelCore) Enabled(lvl zapcore.Level) bool {
+	return lvl >= c.inst.minLevel()
+}
+
+func (c levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelCore{
+		Core: c.Core.With(fields),
+		inst: c.inst,
+	}
+}
+
+func (c levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.inst.levelFor(ent.LoggerName) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func parseLevel(l string) (zapcore.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(l)) {
+	case "debug":
+		return zap.DebugLevel, nil
+	case "info":
+		return zap.InfoLevel, nil
+	case "warn":
+		return zap.WarnLevel, nil
+	case "error":
+		return zap.ErrorLevel, nil
+	default:
+		return zap.InfoLevel, fmt.Errorf("unknown log level %v", l)
+	}
+}
+
+func SetLevel(l string) error {
+	return root.SetLevel(l)
+}
+
+// GetLevel returns the global level, e.g. "info".
+func GetLevel() string {
+	return root.GetLevel()
+}
+
+// SetComponentLevel sets the level of the named logger and its children, regardless of the global level.
+func SetComponentLevel(component, l string) error {
+	return root.SetComponentLevel(component, l)
+}
+
+// ResetComponentLevel removes the override, so the component uses its LOG_LEVEL_<COMPONENT> or the global level
+// again.
+func ResetComponentLevel(component string) {
+	root.ResetComponentLevel(component)
+}
+
+// ComponentLevels returns the overridden levels by component, without the levels from the environment.
+func ComponentLevels() map[string]string {
+	return root.ComponentLevels()
+}
+
+// SetLevel sets the global level of the logger and all loggers derived from it.
+func (l *Logger) SetLevel(lvl string) error {
+	parsed, err := parseLevel(lvl)
+	if err != nil {
+		return err
+	}
+	l.instance().level.SetLevel(parsed)
+	return nil
+}
+
+func (l *Logger) GetLevel() string {
+	return l.instance().level.Level().String()
+}
+
+// SetComponentLevel sets the level of a component, which is independent of the name of l.
+func (l *Logger) SetComponentLevel(component, lvl string) error {
+	parsed, err := parseLevel(lvl)
+	if err != nil {
+		return err
+	}
+	if component = strings.TrimSpace(component); component == "" {
+		return fmt.Errorf("component must not be empty")
+	}
+	l.instance().updateComponentLevels(func(levels map[string]zapcore.Level) {
+		levels[component] = parsed
+	})
+	return nil
+}
+
+func (l *Logger) ResetComponentLevel(component string) {
+	l.instance().updateComponentLevels(func(levels map[string]zapcore.Level) {
+		delete(levels, component)
+	})
+}
+
+func (l *Logger) ComponentLevels() map[string]string {
+	levels := make(map[string]string)
+	for component, lvl := range l.instance().currentComponentLevels() {
+		levels[component] = lvl.String()
+	}
+	return levels
+}
+
+func (inst *instance) currentComponentLevels() map[string]zapcore.Level {
+	if levels := inst.componentLevels.Load(); levels != nil {
+		return *levels
+	}
+	return nil
+}
+
+func (inst *instance) currentEnvLevels() map[string]zapcore.Level {
+	if levels := inst.envLevels.Load(); levels != nil {
+		return *levels
+	}
+	return nil
+}
+
+func (inst *instance) updateComponentLevels(update func(map[string]zapcore.Level)) {
+	inst.componentMu.Lock()
+	defer inst.componentMu.Unlock()
+	levels := maps.Clone(inst.currentComponentLevels())
+	if levels == nil {
+		levels = make(map[string]zapcore.Level)
+	}
+	update(levels)
+	inst.componentLevels.Store(&levels)
+}
+
+// levelFor returns the level of the closest component with a level, e.g. "db" for "db.pool", or the global level.
+// For the same component, an override takes precedence over the environment.
+func (inst *instance) levelFor(name string) zapcore.Level {
+	levels := inst.currentComponentLevels()
+	env := inst.currentEnvLevels()
+	for (len(levels) > 0 || len(env) > 0) && name != "" {
+		if lvl, ok := levels[name]; ok {
+			return lvl
+		}
+		if lvl, ok := env[envName(name)]; ok {
+			return lvl
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return inst.level.Level()
+}
+
+func (inst *instance) minLevel() zapcore.Level {
+	lowest := inst.level.Level()
+	for _, lvl := range inst.currentComponentLevels() {
+		lowest = min(lowest, lvl)
+	}
+	for _, lvl := range inst.currentEnvLevels() {
+		lowest = min(lowest, lvl)
+	}
+	return lowest
+}
+
+// pendingRevert restores a level after a timeout set through LevelHandler.
+type pendingRevert struct {
+	timer    *time.Timer
+	at       time.Time
+	previous string
+}
+
+// setLevelFor sets the global level if component is empty and reverts it after revertAfter if positive.
+// Reverting restores the level from before the first of overlapping changes; an empty previous
+// component level removes the override.
+func (l *Logger) setLevelFor(component, lvl string, revertAfter time.Duration) error {
+	if _, err := parseLevel(lvl); err != nil {
+		return err
+	}
+	inst := l.instance()
+	inst.revertMu.Lock()
+	defer inst.revertMu.Unlock()
+
+	previous := l.GetLevel()
+	if component != "" {
+		previous = l.ComponentLevels()[component]
+	}
+	if pending, ok := inst.reverts[component]; ok {
+		pending.timer.Stop()
+		previous = pending.previous
+		delete(inst.reverts, component)
+	}
+
+	var err error
+	if component == "" {
+		err = l.SetLevel(lvl)
+	} else {
+		err = l.SetComponentLevel(component, lvl)
+	}
+	if err != nil || revertAfter <= 0 {
+		return err
+	}
+
+	pending := &pendingRevert{
+		at:       time.Now().Add(revertAfter),
+		previous: previous,
+	}
+	pending.timer = time.AfterFunc(revertAfter, func() {
+		inst.revertMu.Lock()
+		defer inst.revertMu.Unlock()
+		if inst.reverts[component] != pending {
+			return
+		}
+		delete(inst.reverts, component)
+		switch {
+		case component == "":
+			_ = l.SetLevel(previous)
+		case previous == "":
+			l.ResetComponentLevel(component)
+		default:
+			_ = l.SetComponentLevel(component, previous)
+		}
+	})
+	inst.reverts[component] = pending
+	return nil
+}
+
+// resetLevelFor removes a component override and any pending revert.
+func (l *Logger) resetLevelFor(component string) {
+	inst := l.instance()
+	inst.revertMu.Lock()
+	defer inst.revertMu.Unlock()
+	if pending, ok := inst.reverts[component]; ok {
+		pending.timer.Stop()
+		delete(inst.reverts, component)
+	}
+	l.ResetComponentLevel(component)
+}
+
+// revertAt returns when the level of component is reverted, or nil if no revert is pending.
+func (l *Logger) revertAt(component string) *time.Time {
+	inst := l.instance()
+	inst.revertMu.Lock()
+	defer inst.revertMu.Unlock()
+	if pending, ok := inst.reverts[component]; ok {
+		return &pending.at
+	}
+	return nil
+}
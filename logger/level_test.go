@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetLevels(t *testing.T) {
+	t.Cleanup(func() {
+		for component := range ComponentLevels() {
+			root.resetLevelFor(component)
+		}
+		_ = root.setLevelFor("", getLevel().String(), 0)
+	})
+}
+
+func TestSetLevelChangesOutputWithoutRestart(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	resetLevels(t)
+
+	Debug(debugMsg)
+	assert.Empty(t, sink.String())
+
+	assert.Nil(t, SetLevel("DEBUG"))
+	Debug(debugMsg)
+
+	assert.EqualValues(t, "debug", GetLevel())
+	assert.EqualValues(t, debugMsg, extractLog()["msg"])
+}
+
+func TestSetLevelRejectsUnknownLevel(t *testing.T) {
+	resetLevels(t)
+	before := GetLevel()
+
+	err := SetLevel("verbose")
+
+	assert.EqualValues(t, "unknown log level verbose", err.Error())
+	assert.EqualValues(t, before, GetLevel())
+}
+
+func TestInitLoggerResetsLevelFromEnvironment(t *testing.T) {
+	resetLevels(t)
+	t.Setenv("LOG_LEVEL", "warn")
+	_ = SetLevel("debug")
+
+	initLogger(true, FileOptions{})
+
+	assert.EqualValues(t, "warn", GetLevel())
+}
+
+func TestComponentLevelOverridesGlobalLevel(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	resetLevels(t)
+	assert.Nil(t, SetComponentLevel("db", "debug"))
+	assert.Nil(t, SetComponentLevel("http", "error"))
+
+	Named("db").Named("pool").Debug(debugMsg)
+	m := extractLog()
+	assert.EqualValues(t, debugMsg, m["msg"])
+	assert.EqualValues(t, "db.pool", m["logger"])
+
+	sink.Reset()
+	Named("http").Warn(warnMsg)
+	Debug(debugMsg)
+	assert.Empty(t, sink.String())
+	assert.EqualValues(t, map[string]string{"db": "debug", "http": "error"}, ComponentLevels())
+}
+
+func TestResetComponentLevelRestoresGlobalLevel(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	resetLevels(t)
+	_ = SetComponentLevel("db", "debug")
+
+	ResetComponentLevel("db")
+	Named("db").Debug(debugMsg)
+
+	assert.Empty(t, sink.String())
+	assert.Empty(t, ComponentLevels())
+}
+
+func TestSetComponentLevelRejectsInvalidArguments(t *testing.T) {
+	assert.NotNil(t, SetComponentLevel("db", "verbose"))
+	assert.EqualValues(t, "component must not be empty", SetComponentLevel(" ", "debug").Error())
+}
+
+func TestNamedJoinsNames(t *testing.T) {
+	assert.EqualValues(t, "db", Named("db").Name())
+	assert.EqualValues(t, "db.pool", Named("db").Named("pool").Name())
+	assert.EqualValues(t, "db", Named("db").Named("").Name())
+	assert.EqualValues(t, "db", Named("db").With(Field{Key: "a", Value: 1}).Name())
+}
+
+func TestSetLevelForRevertsAfterTimeout(t *testing.T) {
+	resetLevels(t)
+	_ = SetLevel("info")
+
+	assert.Nil(t, root.setLevelFor("", "debug", 20*time.Millisecond))
+	assert.Nil(t, root.setLevelFor("", "warn", 20*time.Millisecond))
+	assert.Nil(t, root.setLevelFor("db", "debug", 20*time.Millisecond))
+
+	assert.EqualValues(t, "warn", GetLevel())
+	assert.NotNil(t, root.revertAt(""))
+	assert.Eventually(t, func() bool {
+		return GetLevel() == "info" && len(ComponentLevels()) == 0
+	}, time.Second, 5*time.Millisecond)
+	assert.Nil(t, root.revertAt(""))
+}
+
+func TestSetLevelForWithoutTimeoutCancelsRevert(t *testing.T) {
+	resetLevels(t)
+	_ = SetLevel("info")
+
+	_ = root.setLevelFor("", "debug", 20*time.Millisecond)
+	_ = root.setLevelFor("", "error", 0)
+	time.Sleep(50 * time.Millisecond)
+
+	assert.EqualValues(t, "error", GetLevel())
+}
+
+func TestSetLevelForInvalidLevelKeepsPendingRevert(t *testing.T) {
+	resetLevels(t)
+	_ = SetLevel("info")
+	_ = root.setLevelFor("", "debug", time.Hour)
+
+	assert.NotNil(t, root.setLevelFor("", "verbose", 0))
+
+	assert.NotNil(t, root.revertAt(""))
+}
+
+func serveLevel(method, target, body string, opts HandlerOptions) (*httptest.ResponseRecorder, levelResponse) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	LevelHandler(opts).ServeHTTP(rec, req)
+	var resp levelResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func TestLevelHandlerGetReturnsLevels(t *testing.T) {
+	resetLevels(t)
+	_ = SetLevel("warn")
+	_ = SetComponentLevel("db", "debug")
+
+	rec, resp := serveLevel(http.MethodGet, "/loglevel", "", HandlerOptions{})
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, levelResponse{Level: "warn", Components: map[string]componentLevel{"db": {Level: "debug"}}}, resp)
+}
+
+func TestLevelHandlerPutSetsLevelWithRevert(t *testing.T) {
+	resetLevels(t)
+	_ = SetLevel("info")
+
+	rec, resp := serveLevel(http.MethodPut, "/loglevel", `{"level": "debug", "component": "db", "revertAfter": "1h"}`, HandlerOptions{})
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, "info", resp.Level)
+	assert.EqualValues(t, "debug", resp.Components["db"].Level)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *resp.Components["db"].RevertAt, time.Minute)
+}
+
+func TestLevelHandlerDeleteRemovesComponent(t *testing.T) {
+	resetLevels(t)
+	_ = root.setLevelFor("db", "debug", time.Hour)
+
+	rec, resp := serveLevel(http.MethodDelete, "/loglevel?component=db", "", HandlerOptions{})
+
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.Empty(t, resp.Components)
+	assert.Nil(t, root.revertAt("db"))
+}
+
+func TestLevelHandlerRejectsInvalidRequests(t *testing.T) {
+	resetLevels(t)
+	for _, body := range []string{`{`, `{"level": "verbose"}`, `{"level": "debug", "revertAfter": "soon"}`, `{"level": "debug", "revertAfter": "-1m"}`} {
+		rec, _ := serveLevel(http.MethodPut, "/loglevel", body, HandlerOptions{})
+
+		assert.EqualValues(t, http.StatusBadRequest, rec.Code, body)
+	}
+	rec, _ := serveLevel(http.MethodDelete, "/loglevel", "", HandlerOptions{})
+	assert.EqualValues(t, http.StatusBadRequest, rec.Code)
+	rec, _ = serveLevel(http.MethodPatch, "/loglevel", "", HandlerOptions{})
+	assert.EqualValues(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestLevelHandlerRequiresAuthorization(t *testing.T) {
+	rec, _ := serveLevel(http.MethodPut, "/loglevel", `{"level": "debug"}`, HandlerOptions{Token: "t0ken"})
+
+	assert.EqualValues(t, http.StatusUnauthorized, rec.Code)
+	assert.EqualValues(t, `Bearer realm="logs"`, rec.Header().Get("WWW-Authenticate"))
+}
@@ -3,33 +3,62 @@ package logger
 import (
 	"bytes"
 	"fmt"
-	"net/url"
+	"io"
 	"os"
 	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+// Usage:
+//
+//	log, err := logger.New(logger.Options{Level: "debug", Outputs: []string{"stderr"}, File: logger.FileOptions{Filename: "app.log"}})
+//	if err != nil {
+//		return err
+//	}
+//	defer log.Close()
+//	log.Named("db").Info("connected")
+//
+// Loggers returned by New are independent of each other and of the package functions, which write through
+// the default logger configured by Init and the environment.
+
 const (
 	envLogLevel      = "LOG_LEVEL"
 	envLogOutput     = "LOG_OUTPUT"
 	logListMaxLength = 700
-	logListTrimBy    = 100
+	// maxNamedLoggers limits the cached component loggers, as names may be built from request data.
+	maxNamedLoggers = 1000
 )
 
 var (
-	log       logger
-	sink      *MemorySink
-	fileSink  *lumberjack.Logger
-	fileMu    sync.Mutex
-	logListMu sync.Mutex
-	loglist   []LogEntry
+	std  = newInstance()
+	log  = logger{l: root}
+	sink *MemorySink
 )
 
+// Options configures a logger created by New. Empty options fall back to the environment variables
+// documented on the package functions, then to the defaults.
+type Options struct {
+	// Level is the minimum level, e.g. "debug", defaults to LOG_LEVEL or info.
+	Level string
+	// Encoding of the output is json, console or logfmt, defaults to LOG_FORMAT or json.
+	Encoding string
+	// Outputs are paths or "stdout" and "stderr". Without Outputs and Writer, the logger writes to LOG_OUTPUT or stdout.
+	Outputs []string
+	// Writer receives the output in addition to Outputs, e.g. a bytes.Buffer in tests.
+	Writer io.Writer
+	// File writes to a rotated log file in addition to the outputs if File.Filename is set.
+	File FileOptions
+	// Sampling defaults to LOG_SAMPLING or the first 100 entries per second and message, then every 100th.
+	Sampling *SamplingConfig
+	// DisableCaller omits the caller from the output and the log list.
+	DisableCaller bool
+	LogList       LogListOptions
+}
+
 type loggerInterface interface {
 	Print(...any)
 	Printf(string, ...any)
@@ -37,7 +66,7 @@ type loggerInterface interface {
 }
 
 type logger struct {
-	log *zap.Logger
+	l *Logger
 }
 
 type Field struct {
@@ -49,107 +78,194 @@ type MemorySink struct {
 	*bytes.Buffer
 }
 
-type lumberjackSink struct {
-	*lumberjack.Logger
-}
+func (s *MemorySink) Close() error { return nil }
+func (s *MemorySink) Sync() error  { return nil }
 
-func (lumberjackSink) Sync() error {
-	return nil
+// instance holds the state shared by a logger and all loggers derived from it.
+type instance struct {
+	out atomic.Pointer[output]
+
+	level           zap.AtomicLevel
+	componentMu     sync.Mutex
+	componentLevels atomic.Pointer[map[string]zapcore.Level]
+	revertMu        sync.Mutex
+	reverts         map[string]*pendingRevert
+
+	envLevels atomic.Pointer[map[string]zapcore.Level]
+
+	componentConfigMu sync.Mutex
+	componentConfigs  map[string]ComponentConfig
+	// componentOutputs holds the loggers with a component output or sampling, keyed by the components that set them.
+	componentOutputs map[[2]string]*componentLogger
+	componentClosers []func()
+	namedLoggers     sync.Map // component name -> *componentLogger
+	namedLoggerCount int
+
+	logs           atomic.Pointer[logStore]
+	logListSeq     atomic.Uint64
+	logListCleared atomic.Uint64
+
+	subscribersMu  sync.Mutex
+	subscribers    atomic.Pointer[[]*subscriber]
+	droppedEntries atomic.Uint64
 }
 
-type LogEntry struct {
-	LogTime    string
-	LogLevel   string
-	LogMessage string
+// output is the zap setup built from Options. It is replaced as a whole when the logger is reconfigured.
+type output struct {
+	log  *zap.Logger
+	root *componentLogger
+	// encoding is the name of the encoder, which component outputs create with their own colors.
+	encoding string
+	encoder  zapcore.Encoder
+	core     zapcore.Core // writes every entry, levels are applied on top
+	caller   bool
+	options  []zap.Option
+	file     *logFile
+	close    func()
 }
 
-func (s *MemorySink) Close() error { return nil }
-func (s *MemorySink) Sync() error  { return nil }
+func newInstance() *instance {
+	inst := &instance{
+		level:            zap.NewAtomicLevelAt(getLevel()),
+		reverts:          make(map[string]*pendingRevert),
+		componentConfigs: make(map[string]ComponentConfig),
+	}
+	store, _ := newLogStore(LogListOptions{})
+	inst.logs.Store(store)
+	return inst
+}
 
 func init() {
-	initLogger(false, "")
+	initLogger(false, FileOptions{})
 }
 
 func Init(logFileName string) {
-	initLogger(false, logFileName)
+	initLogger(false, FileOptions{Filename: logFileName})
 }
 
-func initLogger(test bool, logFileName string) {
-	closeFileSink()
-
-	encoderConfig := zap.NewProductionEncoderConfig()
-	encoderConfig.LevelKey = "level"
-	encoderConfig.TimeKey = "time"
-	encoderConfig.MessageKey = "msg"
-	encoderConfig.EncodeTime = zapcore.RFC3339TimeEncoder
-	encoderConfig.StacktraceKey = ""
-
-	if logFileName != "" {
-		zap.RegisterSink("lumberjack", func(u *url.URL) (zap.Sink, error) {
-			lj := &lumberjack.Logger{
-				Filename:   u.Opaque,
-				MaxSize:    100, // MB
-				MaxAge:     7,   // days
-				MaxBackups: 7,
-				LocalTime:  false,
-				Compress:   true,
-			}
-			fileMu.Lock()
-			fileSink = lj
-			fileMu.Unlock()
-			return lumberjackSink{
-				Logger: lj,
-			}, nil
-		})
+// New returns a logger configured by opts.
+func New(opts Options) (*Logger, error) {
+	l := &Logger{
+		inst: newInstance(),
+	}
+	if err := l.ConfigureLogList(opts.LogList); err != nil {
+		return nil, err
+	}
+	if err := l.inst.configure(opts); err != nil {
+		return nil, err
 	}
+	return l, nil
+}
 
-	logConfig := zap.NewProductionConfig()
+// initLogger reconfigures the default logger. Its log list, subscribers and component levels are kept.
+func initLogger(test bool, fileOpts FileOptions) {
+	opts := Options{
+		File: fileOpts,
+	}
 	if test {
-		zap.RegisterSink("memory", func(*url.URL) (zap.Sink, error) {
-			return sink, nil
-		})
 		sink = &MemorySink{new(bytes.Buffer)}
-		logConfig.OutputPaths = []string{"memory://"}
-	} else {
-		logConfig.OutputPaths = []string{getOutput()}
+		opts.Writer = sink
 	}
-	if logFileName != "" {
-		logConfig.OutputPaths = append(logConfig.OutputPaths, fmt.Sprintf("lumberjack:%v", logFileName))
+	// An invalid LOG_SAMPLING only fails New, the default logger falls back to the default sampling.
+	var samplingErr error
+	if value := os.Getenv(envLogSampling); value != "" {
+		if _, samplingErr = parseSampling(value); samplingErr != nil {
+			opts.Sampling = new(defaultSampling)
+		}
 	}
-
-	logConfig.Level = zap.NewAtomicLevelAt(getLevel())
-	logConfig.Encoding = "json"
-	logConfig.EncoderConfig = encoderConfig
-
-	var err error
-	if log.log, err = logConfig.Build(zap.AddCaller(), zap.AddCallerSkip(1)); err != nil {
+	if err := std.configure(opts); err != nil {
 		panic(err)
 	}
+	if samplingErr != nil {
+		(&Logger{inst: std}).Error(fmt.Sprintf("ignoring %v", envLogSampling), samplingErr)
+	}
 }
 
-func closeFileSink() {
-	fileMu.Lock()
-	defer fileMu.Unlock()
-	if fileSink == nil {
-		return
+func (inst *instance) configure(opts Options) error {
+	lvl := getLevel()
+	if opts.Level != "" {
+		var err error
+		if lvl, err = parseLevel(opts.Level); err != nil {
+			return err
+		}
+	}
+	encoding := opts.Encoding
+	if encoding == "" {
+		encoding = getFormat()
+	}
+	outputs := opts.Outputs
+	if len(outputs) == 0 && opts.Writer == nil {
+		outputs = []string{getOutput()}
+	}
+	enc, err := newEncoder(encoding, opts.Writer == nil && opts.File.Filename == "" && terminals(outputs...))
+	if err != nil {
+		return err
+	}
+	out := &output{
+		encoding: encoding,
+		encoder:  enc,
+		caller:   !opts.DisableCaller,
+		options:  []zap.Option{zap.AddCallerSkip(2), zap.ErrorOutput(zapcore.Lock(os.Stderr))},
+	}
+	if out.caller {
+		out.options = append(out.options, zap.AddCaller())
+	}
+	sampling := defaultSampling
+	if opts.Sampling != nil {
+		if err := opts.Sampling.validate(); err != nil {
+			return err
+		}
+		sampling = *opts.Sampling
+	} else if value := os.Getenv(envLogSampling); value != "" {
+		s, err := parseSampling(value)
+		if err != nil {
+			return fmt.Errorf("%v: %w", envLogSampling, err)
+		}
+		sampling = *s
 	}
-	_ = fileSink.Close()
-	fileSink = nil
+
+	syncers := make([]zapcore.WriteSyncer, 0, 3)
+	out.close = func() {}
+	if len(outputs) > 0 {
+		ws, closeOutputs, err := zap.Open(outputs...)
+		if err != nil {
+			return err
+		}
+		syncers = append(syncers, ws)
+		out.close = closeOutputs
+	}
+	if opts.Writer != nil {
+		syncers = append(syncers, zapcore.Lock(zapcore.AddSync(opts.Writer)))
+	}
+	if opts.File.Filename != "" {
+		out.file = openFile(opts.File)
+		syncers = append(syncers, out.file)
+	}
+	out.core = zapcore.NewCore(out.encoder, zapcore.NewMultiWriteSyncer(syncers...), zap.DebugLevel)
+	out.log = zap.New(levelCore{
+		Core: out.core,
+		inst: inst,
+	}, out.options...)
+	out.root = &componentLogger{
+		log:     out.log,
+		sampler: inst.newSampler(sampling, out.log),
+	}
+
+	inst.level.SetLevel(lvl)
+	inst.envLevels.Store(new(envComponentLevels()))
+	previous := inst.out.Swap(out)
+	inst.closeComponentOutputs()
+	if previous != nil {
+		previous.root.sampler.flush()
+		previous.file.close()
+		previous.close()
+	}
+	return nil
 }
 
 func getLevel() zapcore.Level {
-	switch strings.ToLower(strings.TrimSpace(os.Getenv(envLogLevel))) {
-	case "debug":
-		return zap.DebugLevel
-	case "info":
-		return zap.InfoLevel
-	case "warn":
-		return zap.WarnLevel
-	case "error":
-		return zap.ErrorLevel
-	default:
-		return zap.InfoLevel
-	}
+	lvl, _ := parseLevel(os.Getenv(envLogLevel))
+	return lvl
 }
 
 func getOutput() string {
@@ -160,32 +276,44 @@ func getOutput() string {
 	return output
 }
 
+// Close closes the outputs of the logger. Loggers derived from it must not be used afterwards.
+func (l *Logger) Close() error {
+	inst := l.instance()
+	inst.closeComponentOutputs()
+	out := inst.out.Load()
+	out.root.sampler.flush()
+	_ = out.log.Sync()
+	out.file.close()
+	out.close()
+	return nil
+}
+
 func GetLogger() loggerInterface {
 	return log
 }
 
 func (l logger) Printf(format string, v ...any) {
 	if len(v) == 0 {
-		Info(format)
+		l.l.Info(format)
 	} else {
-		Info(fmt.Sprintf(format, v...))
+		l.l.Info(fmt.Sprintf(format, v...))
 	}
 }
 
 func (l logger) Print(v ...any) {
-	Info(fmt.Sprint(v...))
+	l.l.Info(fmt.Sprint(v...))
 }
 
 func (l logger) Write(data []byte) (n int, err error) {
 	logMessage := string(data)
 	if strings.Contains(strings.ToLower(logMessage), "error") {
-		Error(logMessage, nil)
+		l.l.Error(logMessage, nil)
 	} else if strings.Contains(strings.ToLower(logMessage), "warn") {
-		Warn(logMessage)
+		l.l.Warn(logMessage)
 	} else if strings.Contains(strings.ToLower(logMessage), "debug") {
-		Debug(logMessage)
+		l.l.Debug(logMessage)
 	} else {
-		Info(logMessage)
+		l.l.Info(logMessage)
 	}
 	return len(data), nil
 }
@@ -199,95 +327,35 @@ func fieldsToZapField(tags []Field) []zapcore.Field {
 	return zapTags
 }
 
-func trimList() {
-	if len(loglist) > logListMaxLength {
-		loglist = loglist[logListTrimBy:]
-	}
-}
-
-func addToLogList(logLevel, msg string) {
-	entry := LogEntry{
-		LogTime:    time.Now().Format(time.RFC3339),
-		LogLevel:   logLevel,
-		LogMessage: msg,
-	}
-	logListMu.Lock()
-	defer logListMu.Unlock()
-	loglist = append(loglist, entry)
-	trimList()
-}
-
-func GetLogList() []LogEntry {
-	logListMu.Lock()
-	defer logListMu.Unlock()
-	return append([]LogEntry(nil), loglist...)
-}
-
-func ClearLogList() {
-	logListMu.Lock()
-	defer logListMu.Unlock()
-	loglist = loglist[:0]
-}
-
 // Debugf writes only to the zap logger and intentionally skips the in-memory log list.
 func Debugf(msg string, a ...any) {
-	m := fmt.Sprintf(msg, a...)
-	log.log.Debug(m)
-	log.log.Sync()
+	root.write(zap.DebugLevel, fmt.Sprintf(msg, a...), nil, false, nil)
 }
 
 func Debug(msg string, tags ...Field) {
-	addToLogList("Debug", msg)
-	zapTags := fieldsToZapField(tags)
-	log.log.Debug(msg, zapTags...)
-	log.log.Sync()
+	root.write(zap.DebugLevel, msg, nil, true, tags)
 }
 
 func Infof(msg string, a ...any) {
-	m := fmt.Sprintf(msg, a...)
-	addToLogList("Info", m)
-	log.log.Info(m)
-	log.log.Sync()
+	root.write(zap.InfoLevel, fmt.Sprintf(msg, a...), nil, true, nil)
 }
 
 func Info(msg string, tags ...Field) {
-	addToLogList("Info", msg)
-	zapTags := fieldsToZapField(tags)
-	log.log.Info(msg, zapTags...)
-	log.log.Sync()
+	root.write(zap.InfoLevel, msg, nil, true, tags)
 }
 
 func Warnf(msg string, a ...any) {
-	m := fmt.Sprintf(msg, a...)
-	addToLogList("Warn", m)
-	log.log.Warn(m)
-	log.log.Sync()
+	root.write(zap.WarnLevel, fmt.Sprintf(msg, a...), nil, true, nil)
 }
 
 func Warn(msg string, tags ...Field) {
-	addToLogList("Warn", msg)
-	zapTags := fieldsToZapField(tags)
-	log.log.Warn(msg, zapTags...)
-	log.log.Sync()
+	root.write(zap.WarnLevel, msg, nil, true, tags)
 }
 
 func Errorf(msg string, a ...any) {
-	m := fmt.Sprintf(msg, a...)
-	addToLogList("Error", m)
-	log.log.Error(m)
-	log.log.Sync()
+	root.write(zap.ErrorLevel, fmt.Sprintf(msg, a...), nil, true, nil)
 }
 
 func Error(msg string, err error, tags ...Field) {
-	var m string
-	if err != nil {
-		m = msg + ": " + err.Error()
-	} else {
-		m = msg
-	}
-	addToLogList("Error", m)
-	zapTags := fieldsToZapField(tags)
-	zapTags = append(zapTags, zap.NamedError("error", err))
-	log.log.Error(msg, zapTags...)
-	log.log.Sync()
+	root.write(zap.ErrorLevel, msg, err, true, tags)
 }
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -74,7 +76,7 @@ func extractLog() map[string]any {
 
 func TestInfoWritesInfo(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "info")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Info(infoMsg)
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "info")
@@ -85,7 +87,7 @@ func TestInfoWritesInfo(t *testing.T) {
 
 func TestInfoWithFieldWritesInfoWithFields(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "info")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Info(infoMsg, Field{
 		Key:   "id",
 		Value: "123",
@@ -100,7 +102,7 @@ func TestInfoWithFieldWritesInfoWithFields(t *testing.T) {
 
 func TestErrorWritesError(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "error")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Error(errorMsg, errors.New(newErrorMsg))
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "error")
@@ -112,7 +114,7 @@ func TestErrorWritesError(t *testing.T) {
 
 func TestErrorWithFieldWritesErrorWithField(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "error")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Error(errorMsg, errors.New(newErrorMsg), Field{
 		Key:   "id",
 		Value: "123",
@@ -128,7 +130,7 @@ func TestErrorWithFieldWritesErrorWithField(t *testing.T) {
 
 func TestDebugWritesDebug(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "debug")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Debug(debugMsg)
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "debug")
@@ -139,7 +141,7 @@ func TestDebugWritesDebug(t *testing.T) {
 
 func TestDebugWithFieldWritesDebugWithField(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "debug")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Debug(debugMsg, Field{
 		Key:   "id",
 		Value: "123",
@@ -153,7 +155,7 @@ func TestDebugWithFieldWritesDebugWithField(t *testing.T) {
 }
 
 func TestPrintPrints(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	log.Print("a", "b")
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "info")
@@ -163,7 +165,7 @@ func TestPrintPrints(t *testing.T) {
 }
 
 func TestPrintfPrints(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	log.Printf(printfMsg)
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "info")
@@ -173,7 +175,7 @@ func TestPrintfPrints(t *testing.T) {
 }
 
 func TestPrintfWithFormatPrints(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	log.Printf("my %s message", "formatted")
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "info")
@@ -183,7 +185,7 @@ func TestPrintfWithFormatPrints(t *testing.T) {
 }
 
 func TestWarnWritesWarn(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Warn(warnMsg)
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "warn")
@@ -193,7 +195,7 @@ func TestWarnWritesWarn(t *testing.T) {
 }
 
 func TestWarnWithFieldWritesWarnWithFields(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Warn(warnMsg, Field{
 		Key:   "id",
 		Value: "123",
@@ -207,7 +209,7 @@ func TestWarnWithFieldWritesWarnWithFields(t *testing.T) {
 }
 
 func TestWriteInfoWritesInfo(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	written, writeErr := log.Write([]byte(infoMsg))
 	m := extractLog()
 	assert.NotNil(t, written)
@@ -220,7 +222,7 @@ func TestWriteInfoWritesInfo(t *testing.T) {
 }
 
 func TestWriteWarnWritesWarn(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	written, writeErr := log.Write([]byte(warnMsg))
 	m := extractLog()
 	assert.NotNil(t, written)
@@ -233,7 +235,7 @@ func TestWriteWarnWritesWarn(t *testing.T) {
 }
 
 func TestWriteErrorWritesError(t *testing.T) {
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	written, writeErr := log.Write([]byte(errorMsg))
 	m := extractLog()
 	assert.NotNil(t, written)
@@ -247,7 +249,7 @@ func TestWriteErrorWritesError(t *testing.T) {
 
 func TestWriteDebugWritesDebug(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "debug")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	written, writeErr := log.Write([]byte(debugMsg))
 	m := extractLog()
 	assert.NotNil(t, written)
@@ -320,7 +322,7 @@ func TestAddToLogListHandlesConcurrentWrites(t *testing.T) {
 
 func TestDebugAddsToLogList(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "debug")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	ClearLogList()
 
 	Debug(debugMsg)
@@ -333,7 +335,7 @@ func TestDebugAddsToLogList(t *testing.T) {
 
 func TestDebugfWritesDebugWithFormat(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "debug")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Debugf("my debug message: %v", "A")
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "debug")
@@ -344,7 +346,7 @@ func TestDebugfWritesDebugWithFormat(t *testing.T) {
 
 func TestInfofWritesInfoWithFormat(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "info")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Infof("my info message: %v", "A")
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "info")
@@ -355,7 +357,7 @@ func TestInfofWritesInfoWithFormat(t *testing.T) {
 
 func TestWarnfWritesWarnWithFormat(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "warn")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Warnf("my warn message: %v", "A")
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "warn")
@@ -366,7 +368,7 @@ func TestWarnfWritesWarnWithFormat(t *testing.T) {
 
 func TestErrorfWritesErrorWithFormat(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "error")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Errorf("my error message: %v", "A")
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "error")
@@ -377,7 +379,7 @@ func TestErrorfWritesErrorWithFormat(t *testing.T) {
 
 func TestErrorWithNilErrorWritesErrorWithoutErrorField(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "error")
-	initLogger(true, "")
+	initLogger(true, FileOptions{})
 	Error(errorMsg, nil)
 	m := extractLog()
 	assert.EqualValues(t, m["level"], "error")
@@ -387,9 +389,9 @@ func TestErrorWithNilErrorWritesErrorWithoutErrorField(t *testing.T) {
 
 func TestLogToFile(t *testing.T) {
 	logFile := filepath.Join(t.TempDir(), "app.log")
-	initLogger(true, logFile)
+	initLogger(true, FileOptions{Filename: logFile})
 	t.Cleanup(func() {
-		initLogger(true, "")
+		initLogger(true, FileOptions{})
 	})
 	Infof("my log message: %v", "A")
 
@@ -398,3 +400,130 @@ func TestLogToFile(t *testing.T) {
 	assert.Contains(t, string(data), "\"level\":\"info\"")
 	assert.Contains(t, string(data), "\"msg\":\"my log message: A\"")
 }
+
+func newTestLogger(t *testing.T, opts Options) (*Logger, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	opts.Writer = &buf
+	l, err := New(opts)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	return l, &buf
+}
+
+func TestNewLoggersAreIndependent(t *testing.T) {
+	initLogger(true, FileOptions{})
+	ClearLogList()
+	debug, debugOut := newTestLogger(t, Options{Level: "debug"})
+	warn, warnOut := newTestLogger(t, Options{Level: "warn"})
+
+	debug.Debug(debugMsg)
+	warn.Named("db").Info(infoMsg)
+	warn.Warn(warnMsg)
+
+	assert.Contains(t, debugOut.String(), debugMsg)
+	assert.NotContains(t, debugOut.String(), warnMsg)
+	assert.NotContains(t, warnOut.String(), infoMsg)
+	assert.Contains(t, warnOut.String(), warnMsg)
+	assert.EqualValues(t, []string{debugMsg}, messages(debug.GetLogList()))
+	assert.EqualValues(t, []string{infoMsg, warnMsg}, messages(warn.GetLogList()))
+	assert.Empty(t, GetLogList())
+	assert.Empty(t, sink.String())
+}
+
+func TestNewLoggerLevelsAreIndependent(t *testing.T) {
+	resetLevels(t)
+	l, out := newTestLogger(t, Options{Level: "info"})
+
+	assert.Nil(t, l.SetComponentLevel("db", "debug"))
+	assert.Nil(t, l.SetLevel("error"))
+	l.Named("db").Debug(debugMsg)
+	l.Warn(warnMsg)
+
+	assert.EqualValues(t, "error", l.GetLevel())
+	assert.EqualValues(t, map[string]string{"db": "debug"}, l.ComponentLevels())
+	assert.NotEqualValues(t, "error", GetLevel())
+	assert.Empty(t, ComponentLevels())
+	assert.Contains(t, out.String(), debugMsg)
+	assert.NotContains(t, out.String(), warnMsg)
+}
+
+func TestNewAppliesOptions(t *testing.T) {
+	l, out := newTestLogger(t, Options{
+		Level:         "debug",
+		Sampling:      &SamplingConfig{First: 2},
+		DisableCaller: true,
+		LogList:       LogListOptions{Size: 3},
+	})
+
+	for range 5 {
+		l.Info(infoMsg)
+	}
+	for _, msg := range []string{"One", "Two", "Three"} {
+		l.Warn(msg)
+	}
+
+	assert.EqualValues(t, 2, strings.Count(out.String(), infoMsg))
+	assert.NotContains(t, out.String(), "caller")
+	entries := l.GetLogList()
+	assert.EqualValues(t, []string{"One", "Two", "Three"}, messages(entries))
+	assert.Empty(t, entries[0].Caller)
+}
+
+func TestNewWritesToOutputsAndFile(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "out.log")
+	logFile := filepath.Join(dir, "app.log")
+	l, err := New(Options{Outputs: []string{output}, File: FileOptions{Filename: logFile}})
+	assert.Nil(t, err)
+
+	l.Info(infoMsg)
+	assert.Nil(t, l.Close())
+
+	for _, path := range []string{output, logFile} {
+		data, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Contains(t, string(data), infoMsg)
+	}
+}
+
+func TestNewUsesEnvironmentForEmptyOptions(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_SAMPLING", "off")
+	l, out := newTestLogger(t, Options{})
+
+	for range 200 {
+		l.Warn(warnMsg)
+	}
+	l.Info(infoMsg)
+
+	assert.EqualValues(t, "warn", l.GetLevel())
+	assert.EqualValues(t, 200, strings.Count(out.String(), warnMsg))
+	assert.NotContains(t, out.String(), infoMsg)
+}
+
+func TestNewRejectsInvalidOptions(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"level":    {Level: "verbose"},
+		"encoding": {Encoding: "xml"},
+		"output":   {Outputs: []string{filepath.Join(t.TempDir(), "missing", "out.log")}},
+		"log list": {LogList: LogListOptions{Size: 1, Quotas: map[string]int{"Error": 2}}},
+	} {
+		l, err := New(opts)
+		assert.NotNil(t, err, name)
+		assert.Nil(t, l, name)
+	}
+}
+
+func TestHandlerServesGivenLogger(t *testing.T) {
+	l, _ := newTestLogger(t, Options{})
+	l.Info(infoMsg)
+
+	rec := serveLogs(Handler(HandlerOptions{Logger: l}), http.MethodGet, "/logs", nil)
+
+	var page pageResponse
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.EqualValues(t, []string{infoMsg}, messages(page.Entries))
+}
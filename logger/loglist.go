@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Usage:
+//
+//	q := logger.Query{
+//		Filter:     logger.Filter{MinLevel: "Warn", Components: []string{"db"}, Contains: "timeout", Fields: map[string]string{"tenantId": "t1"}},
+//		Descending: true,
+//		Limit:      50,
+//	}
+//	page := logger.QueryLogList(q)
+//	q.Cursor = page.Next // the following page
+//
+// The log list keeps the most recent entries in memory. Each entry gets an increasing sequence number that
+// serves as the paging cursor, so pages stay stable while new entries arrive. The entries are kept in
+// fixed-size ring buffers (see ConfigureLogList), so writers never block each other.
+
+const (
+	defaultQueryLimit = 100
+)
+
+var levelRanks = map[string]int{
+	"Debug": 0,
+	"Info":  1,
+	"Warn":  2,
+	"Error": 3,
+}
+
+type LogEntry struct {
+	Seq uint64
+	// LogTime is Time formatted as RFC3339. It is filled in when the entry is read from the log list.
+	LogTime    string
+	Time       time.Time
+	LogLevel   string
+	LogMessage string
+	// Component is the name of the logger, empty for the root logger.
+	Component string
+	// Caller is the file and line that wrote the entry, e.g. "orders/handler.go:42".
+	Caller string
+	// Error is the message of the error passed to Error, if any.
+	Error string
+	// Fields holds the fields of the entry, including those taken from the context. It is nil if there are none.
+	Fields []Field
+}
+
+// Filter selects log list entries. Empty criteria match every entry; all set criteria must match.
+type Filter struct {
+	// Levels matches any of the given levels, e.g. "Warn", case-insensitive.
+	Levels []string
+	// Components matches any of the given components and their children, e.g. "db" matches "db.pool".
+	Components []string
+	// MinLevel matches the given level and all more severe ones.
+	MinLevel string
+	// From and To limit the entry time to [From, To).
+	From time.Time
+	To   time.Time
+	// Contains matches a case-insensitive substring of the message or error.
+	Contains string
+	// Pattern matches the message or error.
+	Pattern *regexp.Regexp
+	// Fields matches entries whose fields have the given values, compared in their %v formatting.
+	Fields map[string]string
+}
+
+type Query struct {
+	Filter
+	// Cursor is the Next value of the previous page; zero starts at the oldest or, if Descending, the newest entry.
+	Cursor     uint64
+	Descending bool
+	// Limit is the maximum number of entries returned, defaults to 100.
+	Limit int
+}
+
+type Page struct {
+	Entries []LogEntry
+	// Next is the cursor of the following page, zero if there are no more matching entries.
+	Next uint64
+}
+
+func (f Filter) Match(e LogEntry) bool {
+	if len(f.Levels) > 0 && !slices.ContainsFunc(f.Levels, func(level string) bool {
+		return strings.EqualFold(level, e.LogLevel)
+	}) {
+		return false
+	}
+	if len(f.Components) > 0 && !slices.ContainsFunc(f.Components, func(component string) bool {
+		return e.Component == component || strings.HasPrefix(e.Component, component+".")
+	}) {
+		return false
+	}
+	if f.MinLevel != "" && levelRank(e.LogLevel) < levelRank(f.MinLevel) {
+		return false
+	}
+	if !f.From.IsZero() && e.Time.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !e.Time.Before(f.To) {
+		return false
+	}
+	if f.Contains != "" {
+		contains := strings.ToLower(f.Contains)
+		if !strings.Contains(strings.ToLower(e.LogMessage), contains) && !strings.Contains(strings.ToLower(e.Error), contains) {
+			return false
+		}
+	}
+	if f.Pattern != nil && !f.Pattern.MatchString(e.LogMessage) && !f.Pattern.MatchString(e.Error) {
+		return false
+	}
+	for key, value := range f.Fields {
+		if !hasField(e.Fields, key, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func levelRank(level string) int {
+	if rank, ok := levelRanks[level]; ok {
+		return rank
+	}
+	for name, rank := range levelRanks {
+		if strings.EqualFold(name, level) {
+			return rank
+		}
+	}
+	return -1
+}
+
+func hasField(fields []Field, key, value string) bool {
+	for _, field := range fields {
+		if field.Key == key && fmt.Sprint(field.Value) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (inst *instance) addEntry(entry LogEntry) {
+	entry.Time = time.Now()
+	if len(entry.Fields) == 0 {
+		entry.Fields = nil
+	}
+	entry.Seq = inst.logListSeq.Add(1)
+	inst.logs.Load().add(&entry)
+	inst.publish(entry)
+}
+
+func GetLogList() []LogEntry {
+	return root.GetLogList()
+}
+
+// ClearLogList removes all entries. Sequence numbers keep counting, so existing cursors stay valid.
+func ClearLogList() {
+	root.ClearLogList()
+}
+
+// QueryLogList returns the entries matching the query, oldest first unless Descending is set.
+func QueryLogList(q Query) Page {
+	return root.QueryLogList(q)
+}
+
+func (l *Logger) GetLogList() []LogEntry {
+	return l.instance().snapshot()
+}
+
+func (l *Logger) ClearLogList() {
+	inst := l.instance()
+	inst.logListCleared.Store(inst.logListSeq.Load())
+	inst.logs.Load().release(inst.logListCleared.Load())
+}
+
+func (l *Logger) QueryLogList(q Query) Page {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	page := Page{
+		Entries: make([]LogEntry, 0),
+	}
+
+	entries := l.instance().snapshot()
+	if q.Descending {
+		slices.Reverse(entries)
+	}
+	for _, e := range entries {
+		if q.Cursor != 0 && (q.Descending && e.Seq >= q.Cursor || !q.Descending && e.Seq <= q.Cursor) {
+			continue
+		}
+		if !q.Match(e) {
+			continue
+		}
+		if len(page.Entries) == limit {
+			page.Next = page.Entries[limit-1].Seq
+			break
+		}
+		page.Entries = append(page.Entries, e)
+	}
+	return page
+}
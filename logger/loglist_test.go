@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func addToLogList(logLevel, msg string, fields ...Field) {
+	std.addEntry(LogEntry{
+		LogLevel:   logLevel,
+		LogMessage: msg,
+		Fields:     fields,
+	})
+}
+
+func fillLogList() {
+	ClearLogList()
+	addToLogList("Debug", "cache miss", Field{Key: "tenantId", Value: "t1"})
+	addToLogList("Info", "order created", Field{Key: "tenantId", Value: "t1"}, Field{Key: "orderId", Value: 42})
+	addToLogList("Warn", "slow request", Field{Key: "tenantId", Value: "t2"})
+	addToLogList("Error", "payment failed: timeout", Field{Key: "tenantId", Value: "t1"})
+	addToLogList("Info", "order shipped", Field{Key: "tenantId", Value: "t2"}, Field{Key: "orderId", Value: 42})
+}
+
+func messages(entries []LogEntry) []string {
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msgs = append(msgs, e.LogMessage)
+	}
+	return msgs
+}
+
+func TestLogListKeepsCallerErrorAndFields(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	ClearLogList()
+
+	Error(errorMsg, errors.New(newErrorMsg), Field{Key: "id", Value: "123"})
+
+	l := GetLogList()
+	assert.EqualValues(t, 1, len(l))
+	assert.EqualValues(t, newErrorMsg, l[0].Error)
+	assert.Contains(t, l[0].Caller, "logger/loglist_test.go:")
+	assert.EqualValues(t, []Field{{Key: "id", Value: "123"}}, l[0].Fields)
+	assert.False(t, l[0].Time.IsZero())
+	assert.EqualValues(t, l[0].Time.Format(time.RFC3339), l[0].LogTime)
+}
+
+func TestLogListSequenceIncreases(t *testing.T) {
+	fillLogList()
+	l := GetLogList()
+	for i := 1; i < len(l); i++ {
+		assert.EqualValues(t, l[i-1].Seq+1, l[i].Seq)
+	}
+}
+
+func TestFilterMatchesLevels(t *testing.T) {
+	fillLogList()
+
+	page := QueryLogList(Query{Filter: Filter{Levels: []string{"info", "WARN"}}})
+
+	assert.EqualValues(t, []string{"order created", "slow request", "order shipped"}, messages(page.Entries))
+}
+
+func TestFilterMatchesMinLevel(t *testing.T) {
+	fillLogList()
+
+	page := QueryLogList(Query{Filter: Filter{MinLevel: "warn"}})
+
+	assert.EqualValues(t, []string{"slow request", "payment failed: timeout"}, messages(page.Entries))
+}
+
+func TestFilterMatchesTimeRange(t *testing.T) {
+	e := LogEntry{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	assert.True(t, Filter{From: e.Time, To: e.Time.Add(time.Second)}.Match(e))
+	assert.False(t, Filter{From: e.Time.Add(time.Second)}.Match(e))
+	assert.False(t, Filter{To: e.Time}.Match(e))
+}
+
+func TestFilterMatchesContainsAndPattern(t *testing.T) {
+	fillLogList()
+
+	contains := QueryLogList(Query{Filter: Filter{Contains: "ORDER"}})
+	pattern := QueryLogList(Query{Filter: Filter{Pattern: regexp.MustCompile(`^(slow|payment)`)}})
+
+	assert.EqualValues(t, []string{"order created", "order shipped"}, messages(contains.Entries))
+	assert.EqualValues(t, []string{"slow request", "payment failed: timeout"}, messages(pattern.Entries))
+}
+
+func TestFilterMatchesError(t *testing.T) {
+	e := LogEntry{LogMessage: "payment failed: gateway timeout", Error: "gateway timeout"}
+
+	assert.True(t, Filter{Contains: "Gateway"}.Match(e))
+	assert.False(t, Filter{Contains: "refund"}.Match(e))
+}
+
+func TestFilterMatchesFields(t *testing.T) {
+	fillLogList()
+
+	page := QueryLogList(Query{Filter: Filter{Fields: map[string]string{"tenantId": "t1", "orderId": "42"}}})
+
+	assert.EqualValues(t, []string{"order created"}, messages(page.Entries))
+}
+
+func TestQueryLogListPagesAscending(t *testing.T) {
+	fillLogList()
+	q := Query{Limit: 2}
+
+	first := QueryLogList(q)
+	q.Cursor = first.Next
+	second := QueryLogList(q)
+	q.Cursor = second.Next
+	third := QueryLogList(q)
+
+	assert.EqualValues(t, []string{"cache miss", "order created"}, messages(first.Entries))
+	assert.EqualValues(t, []string{"slow request", "payment failed: timeout"}, messages(second.Entries))
+	assert.EqualValues(t, []string{"order shipped"}, messages(third.Entries))
+	assert.EqualValues(t, 0, third.Next)
+}
+
+func TestQueryLogListPagesDescending(t *testing.T) {
+	fillLogList()
+	q := Query{Filter: Filter{Fields: map[string]string{"tenantId": "t1"}}, Descending: true, Limit: 2}
+
+	first := QueryLogList(q)
+	q.Cursor = first.Next
+	second := QueryLogList(q)
+
+	assert.EqualValues(t, []string{"payment failed: timeout", "order created"}, messages(first.Entries))
+	assert.EqualValues(t, []string{"cache miss"}, messages(second.Entries))
+	assert.EqualValues(t, 0, second.Next)
+}
+
+func TestQueryLogListCursorSurvivesNewEntries(t *testing.T) {
+	fillLogList()
+	q := Query{Limit: 2}
+	first := QueryLogList(q)
+
+	addToLogList("Info", "new entry")
+	q.Cursor = first.Next
+	second := QueryLogList(q)
+
+	assert.EqualValues(t, []string{"slow request", "payment failed: timeout"}, messages(second.Entries))
+}
+
+func TestQueryLogListDefaultLimit(t *testing.T) {
+	ClearLogList()
+	for range 150 {
+		addToLogList("Info", "I was here")
+	}
+
+	page := QueryLogList(Query{})
+
+	assert.EqualValues(t, defaultQueryLimit, len(page.Entries))
+	assert.NotZero(t, page.Next)
+}
+
+func TestQueryLogListEmptyReturnsEmptyPage(t *testing.T) {
+	ClearLogList()
+
+	page := QueryLogList(Query{})
+
+	assert.NotNil(t, page.Entries)
+	assert.Empty(t, page.Entries)
+	assert.Zero(t, page.Next)
+}
@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// LogListOptions configures the buffers of the in-memory log list.
+type LogListOptions struct {
+	// Size is the total number of entries kept, defaults to 700.
+	Size int
+	// Quotas reserves a dedicated buffer for a level, e.g. {"Error": 100}, so floods of other levels
+	// cannot push its entries out. Levels without a quota share the rest of Size.
+	Quotas map[string]int
+}
+
+// ring is a fixed-size buffer that writers claim slots in with an atomic counter. A slot only moves to a newer
+// entry, so a slow writer that claimed it before the ring wrapped cannot replace the entry of a faster one.
+type ring struct {
+	next  atomic.Uint64
+	slots []atomic.Pointer[LogEntry]
+}
+
+type logStore struct {
+	size   int
+	rings  map[string]*ring
+	shared *ring
+}
+
+func newRing(size int) *ring {
+	return &ring{
+		slots: make([]atomic.Pointer[LogEntry], size),
+	}
+}
+
+func (r *ring) add(e *LogEntry) {
+	if len(r.slots) == 0 {
+		return
+	}
+	n := r.next.Add(1) - 1
+	slot := &r.slots[n%uint64(len(r.slots))]
+	for {
+		old := slot.Load()
+		if old != nil && old.Seq > e.Seq {
+			return
+		}
+		if slot.CompareAndSwap(old, e) {
+			return
+		}
+	}
+}
+
+func newLogStore(opts LogListOptions) (*logStore, error) {
+	size := opts.Size
+	if size <= 0 {
+		size = logListMaxLength
+	}
+	s := &logStore{
+		size:  size,
+		rings: make(map[string]*ring, len(opts.Quotas)),
+	}
+	reserved := 0
+	for level, quota := range opts.Quotas {
+		name, ok := canonicalLevel(level)
+		if !ok {
+			return nil, fmt.Errorf("unknown log level %v", level)
+		}
+		if quota <= 0 {
+			return nil, fmt.Errorf("quota for level %v must be positive", name)
+		}
+		if _, ok := s.rings[name]; ok {
+			return nil, fmt.Errorf("duplicate quota for level %v", name)
+		}
+		s.rings[name] = newRing(quota)
+		reserved += quota
+	}
+	if reserved > size {
+		return nil, fmt.Errorf("quotas of %v entries exceed the log list size of %v", reserved, size)
+	}
+	s.shared = newRing(size - reserved)
+	return s, nil
+}
+
+func canonicalLevel(level string) (string, bool) {
+	for _, name := range levelNames {
+		if strings.EqualFold(name, level) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (s *logStore) add(e *LogEntry) {
+	if r, ok := s.rings[e.LogLevel]; ok {
+		r.add(e)
+		return
+	}
+	s.shared.add(e)
+}
+
+func (s *logStore) each(f func(slot *atomic.Pointer[LogEntry])) {
+	for _, r := range s.rings {
+		for i := range r.slots {
+			f(&r.slots[i])
+		}
+	}
+	for i := range s.shared.slots {
+		f(&s.shared.slots[i])
+	}
+}
+
+// release drops the entries up to seq, unless a writer replaced them in the meantime.
+func (s *logStore) release(seq uint64) {
+	s.each(func(slot *atomic.Pointer[LogEntry]) {
+		if e := slot.Load(); e != nil && e.Seq <= seq {
+			slot.CompareAndSwap(e, nil)
+		}
+	})
+}
+
+// snapshot returns copies of the entries after the last ClearLogList, ordered by sequence number.
+func (inst *instance) snapshot() []LogEntry {
+	cleared := inst.logListCleared.Load()
+	s := inst.logs.Load()
+	entries := make([]LogEntry, 0, s.size)
+	s.each(func(slot *atomic.Pointer[LogEntry]) {
+		if e := slot.Load(); e != nil && e.Seq > cleared {
+			entry := *e
+			entry.LogTime = entry.Time.Format(time.RFC3339)
+			entries = append(entries, entry)
+		}
+	})
+	slices.SortFunc(entries, func(a, b LogEntry) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	return entries
+}
+
+// ConfigureLogList replaces the log list buffers and keeps the most recent entries that fit. Call it during
+// start-up, as entries written while the buffers are switched may be lost.
+func ConfigureLogList(opts LogListOptions) error {
+	return root.ConfigureLogList(opts)
+}
+
+func (l *Logger) ConfigureLogList(opts LogListOptions) error {
+	inst := l.instance()
+	store, err := newLogStore(opts)
+	if err != nil {
+		return err
+	}
+	for _, e := range inst.snapshot() {
+		store.add(&e)
+	}
+	inst.logs.Store(store)
+	return nil
+}
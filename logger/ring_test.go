@@ -0,0 +1,169 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func configureLogList(t testing.TB, opts LogListOptions) {
+	ClearLogList()
+	assert.Nil(t, ConfigureLogList(opts))
+	t.Cleanup(func() {
+		_ = ConfigureLogList(LogListOptions{})
+		ClearLogList()
+	})
+}
+
+func TestConfigureLogListLimitsSize(t *testing.T) {
+	configureLogList(t, LogListOptions{Size: 10})
+	for range 25 {
+		addToLogList("Info", "I was here")
+	}
+
+	l := GetLogList()
+
+	assert.EqualValues(t, 10, len(l))
+	assert.EqualValues(t, l[0].Seq+9, l[9].Seq)
+}
+
+func TestConfigureLogListQuotaKeepsErrors(t *testing.T) {
+	configureLogList(t, LogListOptions{Size: 10, Quotas: map[string]int{"error": 3}})
+	addToLogList("Error", "One")
+	addToLogList("Error", "Two")
+	for range 100 {
+		addToLogList("Debug", "flood")
+	}
+
+	l := GetLogList()
+
+	assert.EqualValues(t, 9, len(l))
+	assert.EqualValues(t, []string{"One", "Two"}, messages(l[:2]))
+	assert.EqualValues(t, 2, len(QueryLogList(Query{Filter: Filter{Levels: []string{"Error"}}}).Entries))
+}
+
+func TestConfigureLogListQuotaOverwritesOldestOfLevel(t *testing.T) {
+	configureLogList(t, LogListOptions{Size: 10, Quotas: map[string]int{"Error": 2}})
+	addToLogList("Error", "One")
+	addToLogList("Error", "Two")
+	addToLogList("Error", "Three")
+
+	assert.EqualValues(t, []string{"Two", "Three"}, messages(GetLogList()))
+}
+
+func TestConfigureLogListKeepsRecentEntries(t *testing.T) {
+	configureLogList(t, LogListOptions{})
+	for _, msg := range []string{"One", "Two", "Three"} {
+		addToLogList("Info", msg)
+	}
+
+	assert.Nil(t, ConfigureLogList(LogListOptions{Size: 2}))
+
+	assert.EqualValues(t, []string{"Two", "Three"}, messages(GetLogList()))
+}
+
+func TestConfigureLogListRejectsInvalidOptions(t *testing.T) {
+	assert.EqualValues(t, "unknown log level fatal", ConfigureLogList(LogListOptions{Quotas: map[string]int{"fatal": 1}}).Error())
+	assert.EqualValues(t, "quota for level Warn must be positive", ConfigureLogList(LogListOptions{Quotas: map[string]int{"warn": 0}}).Error())
+	assert.EqualValues(t, "duplicate quota for level Warn", ConfigureLogList(LogListOptions{Quotas: map[string]int{"warn": 1, "Warn": 2}}).Error())
+	assert.EqualValues(t, "quotas of 11 entries exceed the log list size of 10", ConfigureLogList(LogListOptions{Size: 10, Quotas: map[string]int{"Error": 11}}).Error())
+}
+
+func TestConfigureLogListWithoutSharedBufferDropsOtherLevels(t *testing.T) {
+	configureLogList(t, LogListOptions{Size: 2, Quotas: map[string]int{"Error": 2}})
+	addToLogList("Info", "dropped")
+	addToLogList("Error", "kept")
+
+	assert.EqualValues(t, []string{"kept"}, messages(GetLogList()))
+}
+
+func TestClearLogListReleasesEntries(t *testing.T) {
+	configureLogList(t, LogListOptions{Size: 5})
+	addToLogList("Info", "One")
+
+	ClearLogList()
+
+	assert.Nil(t, std.logs.Load().shared.slots[0].Load())
+	addToLogList("Info", "Two")
+	assert.EqualValues(t, []string{"Two"}, messages(GetLogList()))
+}
+
+func TestRingSlowWriterKeepsNewerEntry(t *testing.T) {
+	r := newRing(1)
+	newer := &LogEntry{Seq: 2, LogMessage: "newer"}
+	r.add(newer)
+
+	r.add(&LogEntry{Seq: 1, LogMessage: "older"})
+
+	assert.Same(t, newer, r.slots[0].Load())
+}
+
+func TestLogListHandlesConcurrentReadsAndWrites(t *testing.T) {
+	configureLogList(t, LogListOptions{Size: 50, Quotas: map[string]int{"Error": 10}})
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for range 500 {
+				if i%2 == 0 {
+					addToLogList("Error", "e")
+				} else {
+					addToLogList("Info", "i")
+				}
+			}
+		})
+	}
+	wg.Go(func() {
+		for range 100 {
+			l := GetLogList()
+			for j := 1; j < len(l); j++ {
+				assert.Less(t, l[j-1].Seq, l[j].Seq)
+			}
+		}
+	})
+	wg.Wait()
+
+	assert.EqualValues(t, 50, len(GetLogList()))
+}
+
+// legacyLogList is the former implementation: a mutex protected slice that drops 100 entries at once
+// when it grows beyond 700.
+type legacyLogList struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+func (l *legacyLogList) add(logLevel, msg string) {
+	entry := LogEntry{
+		LogTime:    time.Now().Format(time.RFC3339),
+		LogLevel:   logLevel,
+		LogMessage: msg,
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > logListMaxLength {
+		l.entries = l.entries[100:]
+	}
+}
+
+func BenchmarkAddToLogList(b *testing.B) {
+	configureLogList(b, LogListOptions{})
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			addToLogList("Info", "I was here")
+		}
+	})
+}
+
+func BenchmarkAddToLogListLegacy(b *testing.B) {
+	var l legacyLogList
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.add("Info", "I was here")
+		}
+	})
+}
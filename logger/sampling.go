@@ -0,0 +1,289 @@
+package logger
+
+import (
+	"fmt"
+	"hash/maphash"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Usage:
+//
+//	logger.New(logger.Options{Sampling: &logger.SamplingConfig{
+//		First:       100,
+//		Thereafter:  100,
+//		Levels:      map[string]logger.SamplingRule{"error": {First: 10}},
+//		Deduplicate: true,
+//	}})
+//
+// or LOG_SAMPLING="100,100 error=10 dedup" (and LOG_SAMPLING_<COMPONENT>, see ConfigureComponent). Sampling counts
+// the entries per component, level and message, so a flood of one message does not hide others. Entries dropped
+// by sampling are missing from the output and the log list alike. Entries below the level only go to the log list
+// and are counted apart, so they neither crowd the log list nor use up the output's share. With Deduplicate, a
+// summary such as "message repeated 523 times: connection refused" reports them at the end of the tick. An invalid
+// LOG_SAMPLING fails New, for the default logger and an invalid LOG_SAMPLING_<COMPONENT> it is logged and ignored.
+
+const (
+	envLogSampling = "LOG_SAMPLING"
+	samplerShards  = 32
+)
+
+var (
+	defaultSampling   = SamplingConfig{First: 100, Thereafter: 100, Tick: time.Second}
+	samplingSeparator = regexp.MustCompile(`\s*([,=])\s*`)
+)
+
+// SamplingRule writes the First entries per tick, then every Thereafter-th, or none if Thereafter is 0.
+// First <= 0 disables sampling.
+type SamplingRule struct {
+	First      int
+	Thereafter int
+}
+
+// SamplingConfig limits repeated entries per Tick, component, level and message.
+type SamplingConfig struct {
+	First      int
+	Thereafter int
+	// Tick defaults to one second.
+	Tick time.Duration
+	// Levels replaces First and Thereafter for a level, e.g. {"error": {First: 10}}.
+	Levels map[string]SamplingRule
+	// Deduplicate writes a summary with the number of dropped entries at the end of the tick.
+	Deduplicate bool
+}
+
+type sampleKey struct {
+	component string
+	level     zapcore.Level
+	msg       string
+	// listOnly counts the entries below the level apart, they only go to the log list and subscribers.
+	listOnly bool
+}
+
+type sampleCounter struct {
+	resetAt time.Time
+	n       int
+	dropped int
+}
+
+type samplerShard struct {
+	mu      sync.Mutex
+	counts  map[sampleKey]*sampleCounter
+	pruneAt time.Time
+}
+
+// sampler decides which entries are written. A nil sampler writes every entry.
+type sampler struct {
+	tick        time.Duration
+	rules       map[zapcore.Level]SamplingRule
+	deduplicate bool
+	seed        maphash.Seed
+	shards      [samplerShards]samplerShard
+	// summarize writes the summary of n dropped entries.
+	summarize func(key sampleKey, n int)
+}
+
+func (s SamplingConfig) validate() error {
+	for level := range s.Levels {
+		if _, err := parseLevel(level); err != nil {
+			return fmt.Errorf("invalid sampling: %w", err)
+		}
+	}
+	return nil
+}
+
+// newSampler returns nil if s samples no level.
+func newSampler(s SamplingConfig, summarize func(key sampleKey, n int)) *sampler {
+	rules := make(map[zapcore.Level]SamplingRule)
+	for _, lvl := range []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel} {
+		rules[lvl] = SamplingRule{First: s.First, Thereafter: s.Thereafter}
+	}
+	for level, rule := range s.Levels {
+		if lvl, err := parseLevel(level); err == nil {
+			rules[lvl] = rule
+		}
+	}
+	for lvl, rule := range rules {
+		if rule.First <= 0 {
+			delete(rules, lvl)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	tick := s.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	return &sampler{
+		tick:        tick,
+		rules:       rules,
+		deduplicate: s.Deduplicate,
+		seed:        maphash.MakeSeed(),
+		summarize:   summarize,
+	}
+}
+
+// allow counts the entry and reports whether it is written.
+func (s *sampler) allow(component string, level zapcore.Level, msg string, listOnly bool) bool {
+	if s == nil {
+		return true
+	}
+	rule, ok := s.rules[level]
+	if !ok {
+		return true
+	}
+	key := sampleKey{
+		component: component,
+		level:     level,
+		msg:       msg,
+		listOnly:  listOnly,
+	}
+	shard := &s.shards[maphash.Comparable(s.seed, key)%samplerShards]
+	now := time.Now()
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if !now.Before(shard.pruneAt) {
+		for k, c := range shard.counts {
+			if !now.Before(c.resetAt) {
+				delete(shard.counts, k)
+			}
+		}
+		shard.pruneAt = now.Add(s.tick)
+	}
+	c := shard.counts[key]
+	if c == nil || !now.Before(c.resetAt) {
+		// A counter with dropped entries stays referenced by its summary timer.
+		c = &sampleCounter{
+			resetAt: now.Add(s.tick),
+		}
+		if shard.counts == nil {
+			shard.counts = make(map[sampleKey]*sampleCounter)
+		}
+		shard.counts[key] = c
+	}
+	c.n++
+	if c.n <= rule.First || rule.Thereafter > 0 && (c.n-rule.First)%rule.Thereafter == 0 {
+		return true
+	}
+	if s.deduplicate {
+		c.dropped++
+		if c.dropped == 1 {
+			time.AfterFunc(c.resetAt.Sub(now), func() {
+				s.report(shard, key, c)
+			})
+		}
+	}
+	return false
+}
+
+func (s *sampler) report(shard *samplerShard, key sampleKey, c *sampleCounter) {
+	shard.mu.Lock()
+	n := c.dropped
+	c.dropped = 0
+	shard.mu.Unlock()
+	if n > 0 {
+		s.summarize(key, n)
+	}
+}
+
+// flush writes the summaries of the current ticks, e.g. before the output is closed.
+func (s *sampler) flush() {
+	if s == nil {
+		return
+	}
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.Lock()
+		counts := make(map[sampleKey]*sampleCounter, len(shard.counts))
+		for key, c := range shard.counts {
+			if c.dropped > 0 {
+				counts[key] = c
+			}
+		}
+		shard.mu.Unlock()
+		for key, c := range counts {
+			s.report(shard, key, c)
+		}
+	}
+}
+
+// newSampler returns a sampler that writes its summaries to zl and the log list.
+func (inst *instance) newSampler(s SamplingConfig, zl *zap.Logger) *sampler {
+	return newSampler(s, func(key sampleKey, n int) {
+		msg := fmt.Sprintf("message repeated %d times: %v", n, key.msg)
+		if n == 1 {
+			msg = "message repeated 1 time: " + key.msg
+		}
+		repeated := Field{Key: "repeated", Value: n}
+		if !key.listOnly {
+			target := zl
+			if key.component != "" && zl.Name() == "" {
+				target = zl.Named(key.component)
+			}
+			target.WithOptions(zap.WithCaller(false)).Log(key.level, msg, zap.Any(repeated.Key, repeated.Value))
+		}
+		inst.addEntry(LogEntry{
+			Component:  key.component,
+			LogLevel:   levelNames[key.level],
+			LogMessage: msg,
+			Fields:     []Field{repeated},
+		})
+	})
+}
+
+// parseSampling reads space separated settings: "first,thereafter" or "off" for all levels, "level=first,thereafter"
+// for a level and "dedup" for summaries.
+func parseSampling(value string) (*SamplingConfig, error) {
+	s := &SamplingConfig{}
+	for _, token := range strings.Fields(samplingSeparator.ReplaceAllString(value, "$1")) {
+		level, rule, isLevel := strings.Cut(token, "=")
+		switch {
+		case strings.EqualFold(token, "off"):
+			s.First, s.Thereafter = 0, 0
+		case strings.EqualFold(token, "dedup"):
+			s.Deduplicate = true
+		case isLevel:
+			if _, err := parseLevel(level); err != nil {
+				return nil, fmt.Errorf("invalid sampling %v: %w", value, err)
+			}
+			r, err := parseSamplingRule(rule)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sampling %v, expected level=first,thereafter", value)
+			}
+			if s.Levels == nil {
+				s.Levels = make(map[string]SamplingRule)
+			}
+			s.Levels[level] = r
+		default:
+			r, err := parseSamplingRule(token)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sampling %v, expected first,thereafter or off", value)
+			}
+			s.First, s.Thereafter = r.First, r.Thereafter
+		}
+	}
+	return s, nil
+}
+
+func parseSamplingRule(value string) (SamplingRule, error) {
+	first, thereafter, _ := strings.Cut(value, ",")
+	var r SamplingRule
+	var err error
+	if r.First, err = strconv.Atoi(first); err != nil {
+		return SamplingRule{}, err
+	}
+	if thereafter != "" {
+		if r.Thereafter, err = strconv.Atoi(thereafter); err != nil {
+			return SamplingRule{}, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,235 @@
+package logger
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func allowed(s *sampler, component string, level string, msg string, n int) []int {
+	lvl, _ := parseLevel(level)
+	var written []int
+	for i := 1; i <= n; i++ {
+		if s.allow(component, lvl, msg, false) {
+			written = append(written, i)
+		}
+	}
+	return written
+}
+
+func TestSamplerWritesFirstThenEveryThereafter(t *testing.T) {
+	s := newSampler(SamplingConfig{First: 2, Thereafter: 3, Tick: time.Hour}, nil)
+
+	assert.EqualValues(t, []int{1, 2, 5, 8}, allowed(s, "", "info", infoMsg, 10))
+}
+
+func TestSamplerWithoutThereafterDropsTheRest(t *testing.T) {
+	s := newSampler(SamplingConfig{First: 2, Tick: time.Hour}, nil)
+
+	assert.EqualValues(t, []int{1, 2}, allowed(s, "", "info", infoMsg, 10))
+}
+
+func TestSamplerCountsPerComponentLevelAndMessage(t *testing.T) {
+	s := newSampler(SamplingConfig{First: 1, Tick: time.Hour}, nil)
+
+	assert.EqualValues(t, []int{1}, allowed(s, "", "info", infoMsg, 3))
+	assert.EqualValues(t, []int{1}, allowed(s, "db", "info", infoMsg, 3))
+	assert.EqualValues(t, []int{1}, allowed(s, "", "warn", infoMsg, 3))
+	assert.EqualValues(t, []int{1}, allowed(s, "", "info", warnMsg, 3))
+}
+
+func TestSamplerAppliesLevelRules(t *testing.T) {
+	s := newSampler(SamplingConfig{Tick: time.Hour, Levels: map[string]SamplingRule{"Error": {First: 1, Thereafter: 2}}}, nil)
+
+	assert.EqualValues(t, []int{1, 2, 3}, allowed(s, "", "info", infoMsg, 3))
+	assert.EqualValues(t, []int{1, 3}, allowed(s, "", "error", errorMsg, 4))
+}
+
+func TestSamplerResetsAfterTick(t *testing.T) {
+	s := newSampler(SamplingConfig{First: 1, Tick: 20 * time.Millisecond}, nil)
+	assert.EqualValues(t, []int{1}, allowed(s, "", "info", infoMsg, 3))
+
+	time.Sleep(30 * time.Millisecond)
+
+	assert.EqualValues(t, []int{1}, allowed(s, "", "info", infoMsg, 3))
+}
+
+func TestSamplerDisabled(t *testing.T) {
+	var s *sampler = newSampler(SamplingConfig{Levels: map[string]SamplingRule{"error": {First: 0}}}, nil)
+
+	assert.Nil(t, s)
+	assert.EqualValues(t, []int{1, 2, 3}, allowed(s, "", "error", errorMsg, 3))
+	s.flush()
+}
+
+func TestSamplerHandlesConcurrentWrites(t *testing.T) {
+	s := newSampler(SamplingConfig{First: 10, Tick: time.Hour}, nil)
+	var mu sync.Mutex
+	written := 0
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			n := len(allowed(s, "", "info", infoMsg, 100))
+			mu.Lock()
+			written += n
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 10, written)
+}
+
+func TestDeduplicateWritesSummaryAfterTick(t *testing.T) {
+	l, out := newTestLogger(t, Options{Sampling: &SamplingConfig{First: 1, Tick: 50 * time.Millisecond, Deduplicate: true}})
+
+	for range 524 {
+		l.Named("db").Error("connection refused", nil)
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(l.GetLogList()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 2, strings.Count(out.String(), "connection refused"))
+	entries := l.GetLogList()
+	assert.EqualValues(t, "message repeated 523 times: connection refused", entries[1].LogMessage)
+	assert.EqualValues(t, "db", entries[1].Component)
+	assert.EqualValues(t, "Error", entries[1].LogLevel)
+	assert.EqualValues(t, []Field{{Key: "repeated", Value: 523}}, entries[1].Fields)
+	assert.Contains(t, out.String(), `"logger":"db","msg":"message repeated 523 times: connection refused","repeated":523}`)
+}
+
+func TestCloseWritesPendingSummaries(t *testing.T) {
+	l, out := newTestLogger(t, Options{Sampling: &SamplingConfig{First: 1, Tick: time.Hour, Deduplicate: true}})
+
+	for range 3 {
+		l.Info(infoMsg)
+	}
+	l.Warn(warnMsg)
+	l.Warn(warnMsg)
+	assert.Nil(t, l.Close())
+
+	assert.Contains(t, out.String(), "message repeated 2 times: "+infoMsg)
+	assert.Contains(t, out.String(), "message repeated 1 time: "+warnMsg)
+	assert.NotContains(t, out.String(), "caller\":\"time/")
+}
+
+func TestComponentSamplingWritesSummaryToComponentOutput(t *testing.T) {
+	resetComponents(t)
+	l, out := newTestLogger(t, Options{})
+	assert.Nil(t, l.ConfigureComponent("db", ComponentConfig{Sampling: &SamplingConfig{First: 1, Tick: time.Hour, Deduplicate: true}}))
+
+	l.Named("db").Info(infoMsg)
+	l.Named("db").Info(infoMsg)
+	l.Info(infoMsg)
+	l.Info(infoMsg)
+	assert.Nil(t, l.ConfigureComponent("db", ComponentConfig{}))
+
+	assert.EqualValues(t, 3, strings.Count(out.String(), `"msg":"`+infoMsg))
+	assert.EqualValues(t, []string{infoMsg, infoMsg, infoMsg, "message repeated 1 time: " + infoMsg}, messages(l.GetLogList()))
+}
+
+func TestSamplingFromEnvironment(t *testing.T) {
+	t.Setenv("LOG_SAMPLING", "off error=1 dedup")
+	l, out := newTestLogger(t, Options{})
+
+	for range 3 {
+		l.Info(infoMsg)
+		l.Error(errorMsg, nil)
+	}
+	assert.Nil(t, l.Close())
+
+	assert.EqualValues(t, 3, strings.Count(out.String(), `"msg":"`+infoMsg))
+	assert.EqualValues(t, 1, strings.Count(out.String(), `"msg":"`+errorMsg))
+	assert.Contains(t, out.String(), "message repeated 2 times: "+errorMsg)
+}
+
+func TestSamplingLimitsEntriesBelowLevelInLogList(t *testing.T) {
+	l, out := newTestLogger(t, Options{Level: "info", Sampling: &SamplingConfig{First: 10, Tick: time.Hour}})
+
+	for range 1000 {
+		l.Debug("flood")
+	}
+	l.Info(infoMsg)
+
+	assert.EqualValues(t, 11, len(l.GetLogList()))
+	assert.EqualValues(t, 1, strings.Count(out.String(), "\n"))
+	assert.Contains(t, out.String(), infoMsg)
+}
+
+func TestSamplingCountsEntriesBelowLevelApart(t *testing.T) {
+	l, out := newTestLogger(t, Options{Level: "info", Sampling: &SamplingConfig{First: 1, Tick: time.Hour, Deduplicate: true}})
+
+	for range 3 {
+		l.Debug(debugMsg)
+	}
+	assert.Nil(t, l.SetLevel("debug"))
+	l.Debug(debugMsg)
+	assert.Nil(t, l.Close())
+
+	assert.EqualValues(t, 1, strings.Count(out.String(), debugMsg))
+	assert.NotContains(t, out.String(), "message repeated 2 times")
+	assert.EqualValues(t, []string{debugMsg, debugMsg, "message repeated 2 times: " + debugMsg},
+		messages(l.GetLogList()))
+}
+
+func TestSamplingFromEnvironmentRejectsInvalidValue(t *testing.T) {
+	t.Setenv("LOG_SAMPLING", "many")
+
+	_, err := New(Options{Writer: io.Discard})
+
+	assert.EqualValues(t, "LOG_SAMPLING: invalid sampling many, expected first,thereafter or off", err.Error())
+}
+
+func TestSamplingFromEnvironmentFallsBackForDefaultLogger(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_SAMPLING", "many")
+	ClearLogList()
+
+	assert.NotPanics(t, func() { initLogger(true, FileOptions{}) })
+
+	m := extractLog()
+	assert.EqualValues(t, "ignoring LOG_SAMPLING", m["msg"])
+	assert.EqualValues(t, "invalid sampling many, expected first,thereafter or off", m["error"])
+	assert.EqualValues(t, defaultSampling.First, std.componentLogger("").sampler.rules[zap.InfoLevel].First)
+}
+
+func TestSamplingRejectsUnknownLevels(t *testing.T) {
+	cfg := &SamplingConfig{Levels: map[string]SamplingRule{"verbose": {First: 1}}}
+
+	_, err := New(Options{Sampling: cfg})
+	assert.EqualValues(t, "invalid sampling: unknown log level verbose", err.Error())
+	assert.NotNil(t, ConfigureComponent("db", ComponentConfig{Sampling: cfg}))
+}
+
+func TestParseSamplingLevelsAndDeduplicate(t *testing.T) {
+	s, err := parseSampling(" 100, 10  error = 5,50 WARN=1 dedup")
+	assert.Nil(t, err)
+	assert.EqualValues(t, &SamplingConfig{
+		First:       100,
+		Thereafter:  10,
+		Levels:      map[string]SamplingRule{"error": {First: 5, Thereafter: 50}, "WARN": {First: 1}},
+		Deduplicate: true,
+	}, s)
+
+	for _, value := range []string{"verbose=1", "error=x", "1,2,3"} {
+		_, err = parseSampling(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func BenchmarkSampler(b *testing.B) {
+	s := newSampler(defaultSampling, func(sampleKey, int) {})
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.allow("db", zap.InfoLevel, infoMsg, false)
+		}
+	})
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyRotate delivers SIGHUP to signals.
+func notifyRotate(signals chan<- os.Signal) {
+	signal.Notify(signals, syscall.SIGHUP)
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package logger
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateOnSignal(t *testing.T) {
+	logFile := initTestFile(t, FileOptions{Compress: new(false), RotateOnSignal: new(true)})
+	Info("before rotation")
+
+	assert.Nil(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		return len(backups(t, logFile)) == 1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,6 @@
+package logger
+
+import "os"
+
+// notifyRotate does nothing, as Windows has no SIGHUP.
+func notifyRotate(chan<- os.Signal) {}
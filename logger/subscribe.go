@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Usage:
+//
+//	errs, cancel := logger.Subscribe(logger.Filter{MinLevel: "Error"})
+//	defer cancel()
+//	for e := range errs {
+//		alerting.Notify(e.LogMessage)
+//	}
+//
+// Subscribers receive every entry added to the log list that matches their filter. Logging never blocks on a
+// slow subscriber: once its buffer is full, entries are dropped according to its drop policy and counted.
+
+const (
+	defaultSubscriberBuffer = 100
+)
+
+type DropPolicy int
+
+const (
+	// DropNewest discards the entry that does not fit into the buffer.
+	DropNewest DropPolicy = iota
+	// DropOldest discards the oldest buffered entry to make room for the new one.
+	DropOldest
+)
+
+type SubscribeOptions struct {
+	// Name identifies the subscriber in SubscriberStats.
+	Name string
+	// BufferSize is the capacity of the channel, defaults to 100.
+	BufferSize int
+	DropPolicy DropPolicy
+}
+
+type SubscriberStats struct {
+	Name     string
+	Buffered int
+	// Queued counts the entries put into the buffer, Dropped those discarded by the drop policy.
+	Queued  uint64
+	Dropped uint64
+}
+
+type subscriber struct {
+	inst    *instance
+	opts    SubscribeOptions
+	filter  Filter
+	mu      sync.RWMutex
+	closed  bool
+	ch      chan LogEntry
+	queued  atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// Subscribe returns a channel with the new entries matching filter and a function that ends the subscription
+// and closes the channel.
+func Subscribe(filter Filter) (<-chan LogEntry, func()) {
+	return root.Subscribe(filter)
+}
+
+func SubscribeWithOptions(filter Filter, opts SubscribeOptions) (<-chan LogEntry, func()) {
+	return root.SubscribeWithOptions(filter, opts)
+}
+
+// Subscribers returns the statistics of the active subscribers in subscription order.
+func Subscribers() []SubscriberStats {
+	return root.Subscribers()
+}
+
+// DroppedEntries returns the number of entries dropped across all subscribers, including cancelled ones.
+func DroppedEntries() uint64 {
+	return root.DroppedEntries()
+}
+
+func (l *Logger) Subscribe(filter Filter) (<-chan LogEntry, func()) {
+	return l.SubscribeWithOptions(filter, SubscribeOptions{})
+}
+
+func (l *Logger) SubscribeWithOptions(filter Filter, opts SubscribeOptions) (<-chan LogEntry, func()) {
+	inst := l.instance()
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultSubscriberBuffer
+	}
+	s := &subscriber{
+		inst:   inst,
+		opts:   opts,
+		filter: filter,
+		ch:     make(chan LogEntry, opts.BufferSize),
+	}
+
+	inst.subscribersMu.Lock()
+	defer inst.subscribersMu.Unlock()
+	inst.subscribers.Store(new(append(inst.currentSubscribers(), s)))
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() {
+			inst.subscribersMu.Lock()
+			inst.subscribers.Store(new(slices.DeleteFunc(slices.Clone(inst.currentSubscribers()), func(other *subscriber) bool {
+				return other == s
+			})))
+			inst.subscribersMu.Unlock()
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.closed = true
+			close(s.ch)
+		})
+	}
+}
+
+func (inst *instance) currentSubscribers() []*subscriber {
+	if subs := inst.subscribers.Load(); subs != nil {
+		return *subs
+	}
+	return nil
+}
+
+func (l *Logger) Subscribers() []SubscriberStats {
+	subs := l.instance().currentSubscribers()
+	stats := make([]SubscriberStats, 0, len(subs))
+	for _, s := range subs {
+		stats = append(stats, SubscriberStats{
+			Name:     s.opts.Name,
+			Buffered: len(s.ch),
+			Queued:   s.queued.Load(),
+			Dropped:  s.dropped.Load(),
+		})
+	}
+	return stats
+}
+
+func (l *Logger) DroppedEntries() uint64 {
+	return l.instance().droppedEntries.Load()
+}
+
+func (inst *instance) publish(entry LogEntry) {
+	subs := inst.currentSubscribers()
+	if len(subs) == 0 {
+		return
+	}
+	for _, s := range subs {
+		if s.filter.Match(entry) {
+			if entry.LogTime == "" {
+				entry.LogTime = entry.Time.Format(time.RFC3339)
+			}
+			s.send(entry)
+		}
+	}
+}
+
+func (s *subscriber) send(entry LogEntry) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return
+	}
+	for {
+		select {
+		case s.ch <- entry:
+			s.queued.Add(1)
+			return
+		default:
+		}
+		if s.opts.DropPolicy != DropOldest {
+			s.drop()
+			return
+		}
+		select {
+		case <-s.ch:
+			s.drop()
+		default:
+		}
+	}
+}
+
+func (s *subscriber) drop() {
+	s.dropped.Add(1)
+	s.inst.droppedEntries.Add(1)
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeReceivesMatchingEntries(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	initLogger(true, FileOptions{})
+	entries, cancel := Subscribe(Filter{MinLevel: "Error"})
+	defer cancel()
+
+	Info(infoMsg)
+	Error(errorMsg, errors.New(newErrorMsg), Field{Key: "id", Value: "123"})
+
+	e := <-entries
+	assert.EqualValues(t, errorMsg+": "+newErrorMsg, e.LogMessage)
+	assert.EqualValues(t, newErrorMsg, e.Error)
+	assert.EqualValues(t, []Field{{Key: "id", Value: "123"}}, e.Fields)
+	assert.NotEmpty(t, e.LogTime)
+	assert.Empty(t, entries)
+}
+
+func TestSubscribeCancelClosesChannel(t *testing.T) {
+	entries, cancel := Subscribe(Filter{})
+
+	cancel()
+	cancel()
+	addToLogList("Info", "after cancel")
+
+	_, ok := <-entries
+	assert.False(t, ok)
+	assert.Empty(t, Subscribers())
+}
+
+func TestSubscribeDropNewestKeepsOldEntries(t *testing.T) {
+	dropped := DroppedEntries()
+	entries, cancel := SubscribeWithOptions(Filter{}, SubscribeOptions{Name: "slow", BufferSize: 2})
+	defer cancel()
+
+	for _, msg := range []string{"One", "Two", "Three", "Four"} {
+		addToLogList("Info", msg)
+	}
+
+	assert.EqualValues(t, []SubscriberStats{{Name: "slow", Buffered: 2, Queued: 2, Dropped: 2}}, Subscribers())
+	assert.EqualValues(t, dropped+2, DroppedEntries())
+	assert.EqualValues(t, "One", (<-entries).LogMessage)
+	assert.EqualValues(t, "Two", (<-entries).LogMessage)
+}
+
+func TestSubscribeDropOldestKeepsNewEntries(t *testing.T) {
+	entries, cancel := SubscribeWithOptions(Filter{}, SubscribeOptions{Name: "latest", BufferSize: 2, DropPolicy: DropOldest})
+	defer cancel()
+
+	for _, msg := range []string{"One", "Two", "Three", "Four"} {
+		addToLogList("Info", msg)
+	}
+
+	assert.EqualValues(t, []SubscriberStats{{Name: "latest", Buffered: 2, Queued: 4, Dropped: 2}}, Subscribers())
+	assert.EqualValues(t, "Three", (<-entries).LogMessage)
+	assert.EqualValues(t, "Four", (<-entries).LogMessage)
+}
+
+func TestSubscribeMultipleSubscribers(t *testing.T) {
+	errs, cancelErrs := SubscribeWithOptions(Filter{Levels: []string{"Error"}}, SubscribeOptions{Name: "errors"})
+	all, cancelAll := SubscribeWithOptions(Filter{}, SubscribeOptions{Name: "all"})
+	defer cancelAll()
+
+	addToLogList("Warn", "Two")
+	addToLogList("Error", "One")
+	cancelErrs()
+
+	assert.EqualValues(t, "One", (<-errs).LogMessage)
+	assert.EqualValues(t, "Two", (<-all).LogMessage)
+	assert.EqualValues(t, "One", (<-all).LogMessage)
+	assert.EqualValues(t, "all", Subscribers()[0].Name)
+}
+
+func TestSubscribeHandlesConcurrentCancel(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 200 {
+				addToLogList("Info", "I was here")
+			}
+		})
+	}
+	for range 20 {
+		wg.Go(func() {
+			entries, cancel := SubscribeWithOptions(Filter{}, SubscribeOptions{BufferSize: 1})
+			addToLogList("Info", "subscribed")
+			<-entries
+			cancel()
+		})
+	}
+	wg.Wait()
+
+	assert.Empty(t, Subscribers())
+}
+
+func BenchmarkAddToLogListWithSubscriber(b *testing.B) {
+	configureLogList(b, LogListOptions{})
+	_, cancel := Subscribe(Filter{MinLevel: "Error"})
+	defer cancel()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			addToLogList("Info", "I was here")
+		}
+	})
+}